@@ -0,0 +1,61 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package cpprand
+
+import "math/bits"
+
+// independentBits returns a 64-bit value built from successive values in
+// [min, max] returned by next, the same way as libstdc++'s
+// std::independent_bits_engine<E, 64, std::uint64_t>.
+//
+func independentBits(next func() uint64, min, max uint64) uint64 {
+	const w = 64
+	r := max - min + 1 // 0 if the range is 2^64
+	m := uint(w)
+	if r != 0 {
+		m = uint(bits.Len64(r) - 1)
+	}
+
+	var n, n0 uint
+	var s0, s1, y0, y1 uint64
+	for i := uint(0); i < 2; i++ {
+		n = (w+m-1)/m + i
+		n0 = n - w%n
+		w0 := w / n
+		s0, s1 = 0, 0
+		if w0 < 64 {
+			s0 = 1 << w0
+			s1 = s0 << 1
+		}
+		y0, y1 = 0, 0
+		if r == 0 {
+			break
+		}
+		y0 = s0 * (r / s0)
+		if s1 != 0 {
+			y1 = s1 * (r / s1)
+		}
+		if r-y0 <= y0/uint64(n) {
+			break
+		}
+	}
+
+	var sum uint64
+	for k := uint(0); k < n; k++ {
+		s, y := s0, y0
+		if k >= n0 {
+			s, y = s1, y1
+		}
+		u := next() - min
+		for y != 0 && u >= y {
+			u = next() - min
+		}
+		if s != 0 {
+			u %= s
+		}
+		sum = s*sum + u
+	}
+	return sum
+}
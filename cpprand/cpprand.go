@@ -0,0 +1,118 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+/*
+Package cpprand provides Go equivalents of the predefined random number engines
+of C++ <random> and of std::seed_seq.
+
+Given the same seed, the engines produce the same values as the C++ engines
+with the same name, as implemented by libstdc++:
+
+	C++                 Go
+	std::minstd_rand0   MinstdRand0
+	std::minstd_rand    MinstdRand
+	std::ranlux24_base  Ranlux24Base
+	std::ranlux48_base  Ranlux48Base
+	std::ranlux24       Ranlux24
+	std::ranlux48       Ranlux48
+	std::knuth_b        KnuthB
+
+std::mt19937 and std::mt19937_64 are implemented by mt19937.Rng32 and
+mt19937.Rng, which can be seeded from a SeedSeq with their SeedSeq method:
+
+	std::seed_seq seq{1, 2, 3};      seq := cpprand.NewSeedSeq(1, 2, 3)
+	std::mt19937_64 rng(seq);        var rng mt19937.Rng
+	                                 rng.SeedSeq(seq)
+
+The zero value of all engines is a valid engine seeded with the same default
+seed as a default constructed C++ engine. Values are returned by the Next
+method, which is the equivalent of operator(). The Uint64 method combines
+successive values into uniformly distributed 64-bit values like
+std::independent_bits_engine<E, 64, std::uint64_t>, so that all engines can be
+used as a math/rand.Source64 or math/rand/v2.Source.
+*/
+package cpprand
+
+// SeedSeq is the equivalent of std::seed_seq. It implements
+// mt19937.SeedSequence.
+//
+type SeedSeq struct {
+	v []uint32
+}
+
+// NewSeedSeq returns a new SeedSeq holding a copy of the seed values v.
+//
+func NewSeedSeq(v ...uint32) *SeedSeq {
+	return &SeedSeq{append([]uint32(nil), v...)}
+}
+
+// Size returns the number of seed values stored in s.
+//
+func (s *SeedSeq) Size() int {
+	return len(s.v)
+}
+
+// Param returns a copy of the seed values stored in s.
+//
+func (s *SeedSeq) Param() []uint32 {
+	return append([]uint32(nil), s.v...)
+}
+
+// Generate fills dst with seed values derived from the values stored in s,
+// exactly like std::seed_seq::generate.
+//
+func (s *SeedSeq) Generate(dst []uint32) {
+	n := uint64(len(dst))
+	if n == 0 {
+		return
+	}
+	for i := range dst {
+		dst[i] = 0x8b8b8b8b
+	}
+	sz := uint64(len(s.v))
+	var t uint64
+	switch {
+	case n >= 623:
+		t = 11
+	case n >= 68:
+		t = 7
+	case n >= 39:
+		t = 5
+	case n >= 7:
+		t = 3
+	default:
+		t = (n - 1) / 2
+	}
+	p := (n - t) / 2
+	q := p + t
+	m := sz + 1
+	if n > m {
+		m = n
+	}
+	// k-1 wraps around for k == 0, like size_t in C++
+	for k := uint64(0); k < m; k++ {
+		arg := dst[k%n] ^ dst[(k+p)%n] ^ dst[(k-1)%n]
+		r1 := 1664525 * (arg ^ arg>>27)
+		r2 := r1
+		switch {
+		case k == 0:
+			r2 += uint32(sz)
+		case k <= sz:
+			r2 += uint32(k%n) + s.v[k-1]
+		default:
+			r2 += uint32(k % n)
+		}
+		dst[(k+p)%n] += r1
+		dst[(k+q)%n] += r2
+		dst[k%n] = r2
+	}
+	for k := m; k < m+n; k++ {
+		arg := dst[k%n] + dst[(k+p)%n] + dst[(k-1)%n]
+		r3 := 1566083941 * (arg ^ arg>>27)
+		r4 := r3 - uint32(k%n)
+		dst[(k+p)%n] ^= r3
+		dst[(k+q)%n] ^= r4
+		dst[k%n] = r4
+	}
+}
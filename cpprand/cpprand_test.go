@@ -0,0 +1,229 @@
+package cpprand_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/db47h/rand64/v3/cpprand"
+	"github.com/db47h/rand64/v3/mt19937"
+)
+
+func ExampleSeedSeq() {
+	// std::seed_seq seq{42};
+	// std::mt19937_64 rng(seq);
+	var rng mt19937.Rng
+	rng.SeedSeq(cpprand.NewSeedSeq(42))
+	fmt.Println(rng.Uint64(), rng.Uint64())
+
+	// Output:
+	// 15673539002298030186 15265596833508273048
+}
+
+type engine interface {
+	Seed(int64)
+	Discard(uint64)
+}
+
+// Expected values generated with libstdc++ 12 (GCC 12).
+//
+// For each seed: the first 3 values, then the 10000th value.
+func TestEngines(t *testing.T) {
+	type result struct {
+		seed int64
+		want [4]uint64
+	}
+	tests := []struct {
+		name string
+		new  func() (engine, func() uint64)
+		res  []result // seed 0 is also the default seed
+	}{
+		{"minstd_rand0", func() (engine, func() uint64) {
+			e := &cpprand.MinstdRand0{}
+			return e, func() uint64 { return uint64(e.Next()) }
+		}, []result{
+			{0, [4]uint64{16807, 282475249, 1622650073, 1043618065}},
+			{42, [4]uint64{705894, 1126542223, 1579310009, 882285790}},
+			{-1, [4]uint64{50421, 847425747, 572982925, 983370548}},
+			{1 << 32, [4]uint64{33614, 564950498, 1097816499, 2087236130}},
+			{2147483647, [4]uint64{16807, 282475249, 1622650073, 1043618065}},
+		}},
+		{"minstd_rand", func() (engine, func() uint64) {
+			e := &cpprand.MinstdRand{}
+			return e, func() uint64 { return uint64(e.Next()) }
+		}, []result{
+			{0, [4]uint64{48271, 182605794, 1291394886, 399268537}},
+			{42, [4]uint64{2027382, 1226992407, 551494037, 1736893025}},
+			{-1, [4]uint64{144813, 547817382, 1726701011, 1197805611}},
+			{1 << 32, [4]uint64{96542, 365211588, 435306125, 798537074}},
+		}},
+		{"ranlux24_base", func() (engine, func() uint64) {
+			e := &cpprand.Ranlux24Base{}
+			return e, func() uint64 { return uint64(e.Next()) }
+		}, []result{
+			{0, [4]uint64{15039276, 16323925, 14283486, 7937952}},
+			{42, [4]uint64{3513247, 6126184, 2057025, 11420168}},
+			{-1, [4]uint64{11090407, 14970617, 4897927, 4581550}},
+			{1 << 32, [4]uint64{15019496, 15209607, 1934716, 2899526}},
+			{2147483647, [4]uint64{7026579, 12252453, 4114049, 7258694}},
+		}},
+		{"ranlux48_base", func() (engine, func() uint64) {
+			e := &cpprand.Ranlux48Base{}
+			return e, e.Next
+		}, []result{
+			{0, [4]uint64{23459059301164, 28639057539807, 276846226770426, 61839128582725}},
+			{42, [4]uint64{134589212629919, 261009543488320, 160567905625071, 211495028287881}},
+			{-1, [4]uint64{122020518509031, 126435940023430, 92060750360101, 159860827624412}},
+			{1 << 32, [4]uint64{22575453646312, 38543793423741, 153965875023559, 100111360846551}},
+		}},
+		{"ranlux24", func() (engine, func() uint64) {
+			e := &cpprand.Ranlux24{}
+			return e, func() uint64 { return uint64(e.Next()) }
+		}, []result{
+			{0, [4]uint64{15039276, 16323925, 14283486, 9901578}},
+			{42, [4]uint64{3513247, 6126184, 2057025, 12424646}},
+			{-1, [4]uint64{11090407, 14970617, 4897927, 2785998}},
+			{1 << 32, [4]uint64{15019496, 15209607, 1934716, 13695818}},
+		}},
+		{"ranlux48", func() (engine, func() uint64) {
+			e := &cpprand.Ranlux48{}
+			return e, e.Next
+		}, []result{
+			{0, [4]uint64{23459059301164, 28639057539807, 276846226770426, 249142670248501}},
+			{42, [4]uint64{134589212629919, 261009543488320, 160567905625071, 151487460625299}},
+			{-1, [4]uint64{122020518509031, 126435940023430, 92060750360101, 25650608767950}},
+			{1 << 32, [4]uint64{22575453646312, 38543793423741, 153965875023559, 242590279331736}},
+		}},
+		{"knuth_b", func() (engine, func() uint64) {
+			e := &cpprand.KnuthB{}
+			return e, func() uint64 { return uint64(e.Next()) }
+		}, []result{
+			{0, [4]uint64{152607844, 823378840, 578354438, 1112339016}},
+			{42, [4]uint64{1095041257, 544618625, 942678115, 1060807721}},
+			{-1, [4]uint64{1058486182, 1747390045, 666368007, 1879268178}},
+			{1 << 32, [4]uint64{57010117, 202055088, 1486775473, 805567685}},
+		}},
+	}
+	for _, tt := range tests {
+		check := func(e engine, next func() uint64, seed int64, want *[4]uint64) {
+			for i := 0; i < 3; i++ {
+				if v := next(); v != want[i] {
+					t.Fatalf("%s(%d): value %d: expected %d, got %d", tt.name, seed, i, want[i], v)
+				}
+			}
+			e.Discard(9996)
+			if v := next(); v != want[3] {
+				t.Fatalf("%s(%d): value 10000: expected %d, got %d", tt.name, seed, want[3], v)
+			}
+		}
+		// default constructed
+		e, next := tt.new()
+		check(e, next, 0, &tt.res[0].want)
+		for _, r := range tt.res {
+			e, next := tt.new()
+			e.Seed(r.seed)
+			check(e, next, r.seed, &r.want)
+		}
+	}
+}
+
+// Expected values generated with libstdc++ 12 (GCC 12), using
+// std::independent_bits_engine<E, 64, std::uint64_t> seeded with 42.
+func TestEngines_Uint64(t *testing.T) {
+	tests := []struct {
+		name string
+		e    interface {
+			Seed(int64)
+			Uint64() uint64
+		}
+		want [3]uint64
+	}{
+		{"minstd_rand0", &cpprand.MinstdRand0{}, [3]uint64{6209102050322701240, 11526877513781339352, 5439087111005651225}},
+		{"minstd_rand", &cpprand.MinstdRand{}, [3]uint64{17833032532197581204, 7707126492295442372, 1480264102082392072}},
+		{"ranlux24_base", &cpprand.Ranlux24Base{}, [3]uint64{12456111451177706305, 8028544334413074952, 11306553266010922148}},
+		{"ranlux48_base", &cpprand.Ranlux48Base{}, [3]uint64{9094346129832633152, 2376251263499345181, 16149095331091294632}},
+		{"ranlux24", &cpprand.Ranlux24{}, [3]uint64{12456111451177706305, 8028544334413074952, 11306553266010922148}},
+		{"ranlux48", &cpprand.Ranlux48{}, [3]uint64{9094346129832633152, 2376251263499345181, 16149095331091294632}},
+		{"knuth_b", &cpprand.KnuthB{}, [3]uint64{2884350563081789538, 10000809229048283100, 7926069638039255646}},
+	}
+	for _, tt := range tests {
+		tt.e.Seed(42)
+		for i, w := range tt.want {
+			if v := tt.e.Uint64(); v != w {
+				t.Fatalf("%s: value %d: expected %d, got %d", tt.name, i, w, v)
+			}
+		}
+	}
+}
+
+// Expected values generated with libstdc++ 12 (GCC 12).
+func TestSeedSeq_Generate(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []uint32 // first values and last value
+	}{
+		{1, []uint32{160436306, 160436306}},
+		{2, []uint32{1647260224, 1419692085}},
+		{3, []uint32{1938867353, 2241105165, 750914718}},
+		{7, []uint32{2885460551, 800443977, 356027423, 3514375378, 3550258717}},
+		{10, []uint32{3856504558, 1286061837, 2478446086, 4286950948, 1882640836}},
+		{39, []uint32{2537477563, 3855437952, 361356708, 2677200982, 3344810242}},
+		{68, []uint32{244280571, 1431533554, 885565331, 1393010998, 1048698236}},
+		{100, []uint32{1237031998, 3767056533, 1281985462, 115394221, 3028146325}},
+		{623, []uint32{2674421774, 1142336420, 3773973600, 839275489, 3564524271}},
+		{624, []uint32{2918214674, 3523201532, 1215902302, 2674967086, 3760964842}},
+		{1248, []uint32{625143415, 1895050383, 2969874457, 4159063459, 861310228}},
+	}
+	seq := cpprand.NewSeedSeq(1, 2, 3, 0xffffffff)
+	for _, tt := range tests {
+		v := make([]uint32, tt.n)
+		seq.Generate(v)
+		for i, w := range tt.want[:len(tt.want)-1] {
+			if v[i] != w {
+				t.Fatalf("n = %d: value %d: expected %d, got %d", tt.n, i, w, v[i])
+			}
+		}
+		if w := tt.want[len(tt.want)-1]; v[tt.n-1] != w {
+			t.Fatalf("n = %d: last value: expected %d, got %d", tt.n, w, v[tt.n-1])
+		}
+	}
+
+	v := make([]uint32, 5)
+	cpprand.NewSeedSeq().Generate(v)
+	for i, w := range []uint32{505382999, 163489202, 3932644188, 763126080, 73937346} {
+		if v[i] != w {
+			t.Fatalf("empty seed_seq: value %d: expected %d, got %d", i, w, v[i])
+		}
+	}
+}
+
+// Expected values generated with libstdc++ 12 (GCC 12).
+func TestSeedSeq_mt19937(t *testing.T) {
+	var rng mt19937.Rng
+	rng.SeedSeq(cpprand.NewSeedSeq(1, 2, 3, 4, 5, 6, 7, 8, 9, 10))
+	for _, w := range []uint64{17941265764200496625, 800903460341458590} {
+		if v := rng.Uint64(); v != w {
+			t.Fatalf("mt19937_64: expected %d, got %d", w, v)
+		}
+	}
+	rng.SeedSeq(cpprand.NewSeedSeq(42))
+	for i := 0; i < 1002; i++ {
+		rng.Uint64()
+	}
+	if v := rng.Uint64(); v != 8847702236660718509 {
+		t.Fatalf("mt19937_64: expected 8847702236660718509, got %d", v)
+	}
+
+	var rng32 mt19937.Rng32
+	rng32.SeedSeq(cpprand.NewSeedSeq(42))
+	for _, w := range []uint32{2614276261, 2440701700} {
+		if v := rng32.Uint32(); v != w {
+			t.Fatalf("mt19937: expected %d, got %d", w, v)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		rng32.Uint32()
+	}
+	if v := rng32.Uint32(); v != 3960748560 {
+		t.Fatalf("mt19937: expected 3960748560, got %d", v)
+	}
+}
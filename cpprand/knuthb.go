@@ -0,0 +1,68 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package cpprand
+
+const knuthBK = 256
+
+// KnuthB is the equivalent of std::knuth_b, a shuffle order engine that
+// shuffles the output of a MinstdRand0 with a table of 256 values.
+//
+type KnuthB struct {
+	b    MinstdRand0
+	v    [knuthBK]uint32
+	y    uint32
+	init bool
+}
+
+// Seed seeds the engine like seed(value) in C++.
+//
+func (e *KnuthB) Seed(seed int64) {
+	e.b.Seed(seed)
+	e.fill()
+}
+
+func (e *KnuthB) fill() {
+	for i := range e.v {
+		e.v[i] = e.b.Next()
+	}
+	e.y = e.b.Next()
+	e.init = true
+}
+
+// Next returns the next value of the sequence.
+//
+func (e *KnuthB) Next() uint32 {
+	if !e.init {
+		e.fill()
+	}
+	// libstdc++ computes k * (y - min) / (max - min + 1) with long doubles,
+	// which always rounds to the same index as integer arithmetic.
+	j := uint64(e.y-1) * knuthBK / (minstdM - 1)
+	e.y = e.v[j]
+	e.v[j] = e.b.Next()
+	return e.y
+}
+
+// Discard advances the engine's state by n steps.
+//
+func (e *KnuthB) Discard(n uint64) {
+	for ; n != 0; n-- {
+		e.Next()
+	}
+}
+
+// Uint64 returns a pseudo-random 64-bit value built from successive values of
+// the sequence, like
+// std::independent_bits_engine<std::knuth_b, 64, std::uint64_t>.
+//
+func (e *KnuthB) Uint64() uint64 {
+	return independentBits(func() uint64 { return uint64(e.Next()) }, 1, minstdM-1)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (e *KnuthB) Int63() int64 {
+	return int64(e.Uint64() >> 1)
+}
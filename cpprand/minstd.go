@@ -0,0 +1,114 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package cpprand
+
+const minstdM = 2147483647
+
+// MinstdRand0 is the equivalent of std::minstd_rand0, the Lehmer generator
+// with multiplier 16807 and modulus 2^31-1. It returns values in
+// [1, 2147483646].
+//
+type MinstdRand0 struct {
+	x uint64 // 0 stands for the default state 1
+}
+
+// Seed seeds the engine like seed(value) in C++.
+//
+func (e *MinstdRand0) Seed(seed int64) {
+	e.x = minstdSeed(seed)
+}
+
+// Next returns the next value of the sequence.
+//
+func (e *MinstdRand0) Next() uint32 {
+	return minstdNext(&e.x, 16807)
+}
+
+// Discard advances the engine's state by n steps.
+//
+func (e *MinstdRand0) Discard(n uint64) {
+	minstdDiscard(&e.x, 16807, n)
+}
+
+// Uint64 returns a pseudo-random 64-bit value built from successive values of
+// the sequence, like
+// std::independent_bits_engine<std::minstd_rand0, 64, std::uint64_t>.
+//
+func (e *MinstdRand0) Uint64() uint64 {
+	return independentBits(func() uint64 { return uint64(e.Next()) }, 1, minstdM-1)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (e *MinstdRand0) Int63() int64 {
+	return int64(e.Uint64() >> 1)
+}
+
+// MinstdRand is the equivalent of std::minstd_rand, the Lehmer generator
+// with multiplier 48271 and modulus 2^31-1. It returns values in
+// [1, 2147483646].
+//
+type MinstdRand struct {
+	x uint64 // 0 stands for the default state 1
+}
+
+// Seed seeds the engine like seed(value) in C++.
+//
+func (e *MinstdRand) Seed(seed int64) {
+	e.x = minstdSeed(seed)
+}
+
+// Next returns the next value of the sequence.
+//
+func (e *MinstdRand) Next() uint32 {
+	return minstdNext(&e.x, 48271)
+}
+
+// Discard advances the engine's state by n steps.
+//
+func (e *MinstdRand) Discard(n uint64) {
+	minstdDiscard(&e.x, 48271, n)
+}
+
+// Uint64 returns a pseudo-random 64-bit value built from successive values of
+// the sequence, like
+// std::independent_bits_engine<std::minstd_rand, 64, std::uint64_t>.
+//
+func (e *MinstdRand) Uint64() uint64 {
+	return independentBits(func() uint64 { return uint64(e.Next()) }, 1, minstdM-1)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (e *MinstdRand) Int63() int64 {
+	return int64(e.Uint64() >> 1)
+}
+
+func minstdSeed(seed int64) uint64 {
+	// seed(0) sets the state to 1, which is also the zero value.
+	return uint64(seed) % minstdM
+}
+
+func minstdNext(x *uint64, a uint64) uint32 {
+	if *x == 0 {
+		*x = 1
+	}
+	*x = *x * a % minstdM
+	return uint32(*x)
+}
+
+// minstdDiscard computes x * a^n mod m by square-and-multiply.
+//
+func minstdDiscard(x *uint64, a, n uint64) {
+	if *x == 0 {
+		*x = 1
+	}
+	for ; n != 0; n >>= 1 {
+		if n&1 != 0 {
+			*x = *x * a % minstdM
+		}
+		a = a * a % minstdM
+	}
+}
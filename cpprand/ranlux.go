@@ -0,0 +1,244 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package cpprand
+
+const swcDefaultSeed = 19780503
+
+// swc is a subtract with carry engine with word size w, short lag s and long
+// lag r, like std::subtract_with_carry_engine.
+//
+type swc struct {
+	x      [24]uint64 // only the first r words are used
+	carry  uint64
+	p      int
+	seeded bool
+}
+
+func (e *swc) seed(seed uint64, w uint, r int) {
+	// seeded by std::linear_congruential_engine<result_type, 40014, 0, 2147483563>
+	lcg := seed
+	if seed == 0 {
+		lcg = swcDefaultSeed
+	}
+	if lcg %= 2147483563; lcg == 0 {
+		lcg = 1
+	}
+	n := (w + 31) / 32
+	for i := 0; i < r; i++ {
+		var sum uint64
+		for j := uint(0); j < n; j++ {
+			lcg = lcg * 40014 % 2147483563
+			sum += lcg << (32 * j)
+		}
+		e.x[i] = sum & (1<<w - 1)
+	}
+	e.carry = 0
+	if e.x[r-1] == 0 {
+		e.carry = 1
+	}
+	e.p = 0
+	e.seeded = true
+}
+
+func (e *swc) next(w uint, s, r int) uint64 {
+	if !e.seeded {
+		e.seed(swcDefaultSeed, w, r)
+	}
+	ps := e.p - s
+	if ps < 0 {
+		ps += r
+	}
+	var xi uint64
+	if e.x[ps] >= e.x[e.p]+e.carry {
+		xi = e.x[ps] - e.x[e.p] - e.carry
+		e.carry = 0
+	} else {
+		xi = 1<<w - e.x[e.p] - e.carry + e.x[ps]
+		e.carry = 1
+	}
+	e.x[e.p] = xi
+	if e.p++; e.p >= r {
+		e.p = 0
+	}
+	return xi
+}
+
+// Ranlux24Base is the equivalent of std::ranlux24_base, a subtract with carry
+// engine returning 24 bits values.
+//
+type Ranlux24Base struct {
+	e swc
+}
+
+// Seed seeds the engine like seed(value) in C++.
+//
+func (e *Ranlux24Base) Seed(seed int64) {
+	e.e.seed(uint64(seed), 24, 24)
+}
+
+// Next returns the next value of the sequence.
+//
+func (e *Ranlux24Base) Next() uint32 {
+	return uint32(e.e.next(24, 10, 24))
+}
+
+// Discard advances the engine's state by n steps.
+//
+func (e *Ranlux24Base) Discard(n uint64) {
+	for ; n != 0; n-- {
+		e.e.next(24, 10, 24)
+	}
+}
+
+// Uint64 returns a pseudo-random 64-bit value built from successive values of
+// the sequence, like
+// std::independent_bits_engine<std::ranlux24_base, 64, std::uint64_t>.
+//
+func (e *Ranlux24Base) Uint64() uint64 {
+	return independentBits(func() uint64 { return uint64(e.Next()) }, 0, 1<<24-1)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (e *Ranlux24Base) Int63() int64 {
+	return int64(e.Uint64() >> 1)
+}
+
+// Ranlux48Base is the equivalent of std::ranlux48_base, a subtract with carry
+// engine returning 48 bits values.
+//
+type Ranlux48Base struct {
+	e swc
+}
+
+// Seed seeds the engine like seed(value) in C++.
+//
+func (e *Ranlux48Base) Seed(seed int64) {
+	e.e.seed(uint64(seed), 48, 12)
+}
+
+// Next returns the next value of the sequence.
+//
+func (e *Ranlux48Base) Next() uint64 {
+	return e.e.next(48, 5, 12)
+}
+
+// Discard advances the engine's state by n steps.
+//
+func (e *Ranlux48Base) Discard(n uint64) {
+	for ; n != 0; n-- {
+		e.e.next(48, 5, 12)
+	}
+}
+
+// Uint64 returns a pseudo-random 64-bit value built from successive values of
+// the sequence, like
+// std::independent_bits_engine<std::ranlux48_base, 64, std::uint64_t>.
+//
+func (e *Ranlux48Base) Uint64() uint64 {
+	return independentBits(func() uint64 { return uint64(e.Next()) }, 0, 1<<48-1)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (e *Ranlux48Base) Int63() int64 {
+	return int64(e.Uint64() >> 1)
+}
+
+// Ranlux24 is the equivalent of std::ranlux24, a discard block engine that
+// only uses 23 out of every 223 values of a Ranlux24Base.
+//
+type Ranlux24 struct {
+	b Ranlux24Base
+	n int
+}
+
+// Seed seeds the engine like seed(value) in C++.
+//
+func (e *Ranlux24) Seed(seed int64) {
+	e.b.Seed(seed)
+	e.n = 0
+}
+
+// Next returns the next value of the sequence.
+//
+func (e *Ranlux24) Next() uint32 {
+	if e.n >= 23 {
+		e.b.Discard(uint64(223 - e.n))
+		e.n = 0
+	}
+	e.n++
+	return e.b.Next()
+}
+
+// Discard advances the engine's state by n steps.
+//
+func (e *Ranlux24) Discard(n uint64) {
+	for ; n != 0; n-- {
+		e.Next()
+	}
+}
+
+// Uint64 returns a pseudo-random 64-bit value built from successive values of
+// the sequence, like
+// std::independent_bits_engine<std::ranlux24, 64, std::uint64_t>.
+//
+func (e *Ranlux24) Uint64() uint64 {
+	return independentBits(func() uint64 { return uint64(e.Next()) }, 0, 1<<24-1)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (e *Ranlux24) Int63() int64 {
+	return int64(e.Uint64() >> 1)
+}
+
+// Ranlux48 is the equivalent of std::ranlux48, a discard block engine that
+// only uses 11 out of every 389 values of a Ranlux48Base.
+//
+type Ranlux48 struct {
+	b Ranlux48Base
+	n int
+}
+
+// Seed seeds the engine like seed(value) in C++.
+//
+func (e *Ranlux48) Seed(seed int64) {
+	e.b.Seed(seed)
+	e.n = 0
+}
+
+// Next returns the next value of the sequence.
+//
+func (e *Ranlux48) Next() uint64 {
+	if e.n >= 11 {
+		e.b.Discard(uint64(389 - e.n))
+		e.n = 0
+	}
+	e.n++
+	return e.b.Next()
+}
+
+// Discard advances the engine's state by n steps.
+//
+func (e *Ranlux48) Discard(n uint64) {
+	for ; n != 0; n-- {
+		e.Next()
+	}
+}
+
+// Uint64 returns a pseudo-random 64-bit value built from successive values of
+// the sequence, like
+// std::independent_bits_engine<std::ranlux48, 64, std::uint64_t>.
+//
+func (e *Ranlux48) Uint64() uint64 {
+	return independentBits(func() uint64 { return uint64(e.Next()) }, 0, 1<<48-1)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (e *Ranlux48) Int63() int64 {
+	return int64(e.Uint64() >> 1)
+}
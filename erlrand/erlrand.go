@@ -0,0 +1,367 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+/*
+Package erlrand provides sources of pseudo-random numbers that are bit-exact
+compatible with the exsss, exrop and exs1024s algorithms of Erlang/OTP's rand
+module.
+
+exsss, the default algorithm since OTP 22, is xorshift116**: a xorshift128+
+linear engine shrunk to two 58-bit words, with the StarStar scrambler of
+xoshiro256**. exrop is xoroshiro116+, the 58-bit variant of xoroshiro128+. The
+58-bit words allow Erlang to do all the arithmetic on small integers.
+
+Given the same seed, Erlang and Go produce the same values:
+
+	Erlang                              Go
+	rand:seed(exsss, N)                 r := erlrand.NewExsss(N)
+	rand:seed(exsss, {A1, A2, A3})      r.SeedTuple(A1, A2, A3)
+	rand:uniform()                      r.Uniform()
+	rand:uniform(N)                     r.UniformN(N)
+	rand:jump()                         r.Jump()
+
+and likewise for exrop with Exrop and exs1024s with Exs1024s. For ranges
+larger than 2^58, the UniformN methods of Exsss and Exrop combine two outputs
+of the generator like Erlang does.
+
+exs1024s is xorshift1024*, which works on full 64-bit words. Its jump
+polynomial is the JUMP constant of the reference xorshift1024* implementation.
+
+Period: 2^116-1 (exsss, exrop), 2^1024-1 (exs1024s). State size: 116 bits
+(exsss, exrop), 1024 bits (exs1024s).
+*/
+package erlrand
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/db47h/rand64/v3/internal/gf2"
+)
+
+const mask58 = 1<<58 - 1
+
+// Exsss encapsulates an Erlang exsss (xorshift116**) PRNG.
+//
+// The zero value is not a valid state; an Exsss must be seeded before use.
+//
+type Exsss struct {
+	s0, s1 uint64
+}
+
+// NewExsss returns a new Exsss seeded like rand:seed(exsss, seed).
+//
+func NewExsss(seed int64) *Exsss {
+	r := &Exsss{}
+	r.Seed(seed)
+	return r
+}
+
+// Seed seeds r like rand:seed(exsss, seed). The two state words are the first
+// two non-zero 58-bit outputs of a splitmix64 generator seeded with seed.
+//
+func (r *Exsss) Seed(seed int64) {
+	var x uint64
+	r.s0, x = seed58(uint64(seed))
+	r.s1, _ = seed58(x)
+}
+
+// SeedTuple seeds r like rand:seed(exsss, {a1, a2, a3}).
+//
+func (r *Exsss) SeedTuple(a1, a2, a3 int64) {
+	_, x := seed58(uint64(a1))
+	r.s0, x = seed58(uint64(a2) ^ x)
+	r.s1, _ = seed58(uint64(a3) ^ x)
+}
+
+// Next returns the next raw 58-bit output of the generator. Uniform and
+// UniformN are built on top of it.
+//
+func (r *Exsss) Next() uint64 {
+	s0, s1 := r.s0, r.s1
+	r.s0, r.s1 = exsNext(s0, s1)
+	// StarStar scrambler: rotl58(s1 * 5, 7) * 9
+	v := s1 * 5 & mask58
+	v = (v<<7 | v>>(58-7)) & mask58
+	return v * 9 & mask58
+}
+
+// Uniform returns a pseudo-random float64 in [0.0, 1.0), like rand:uniform().
+//
+func (r *Exsss) Uniform() float64 {
+	return float64(r.Next()>>5) * (1.0 / (1 << 53))
+}
+
+// UniformN returns a pseudo-random integer in [1, n], like rand:uniform(n).
+//
+// UniformN panics if n == 0.
+//
+func (r *Exsss) UniformN(n uint64) uint64 {
+	return uniformN(r.Next, n, 0)
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64, built from the top
+// bits of two successive 58-bit outputs. Erlang has no equivalent; it allows
+// the use of an Exsss as a math/rand.Source64.
+//
+func (r *Exsss) Uint64() uint64 {
+	hi := r.Next()
+	return hi<<6 | r.Next()>>52
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (r *Exsss) Int63() int64 {
+	return int64(r.Uint64() >> 1)
+}
+
+// Jump is equivalent to 2^64 calls to Next, like rand:jump(). It can be used
+// to generate 2^52 non-overlapping subsequences for parallel computations.
+//
+func (r *Exsss) Jump() {
+	r.s0, r.s1 = jump(r.s0, r.s1, exsNext, &exsJumpPoly)
+}
+
+// Advance advances the generator's state by n steps. It is equivalent to n
+// calls to Next.
+//
+func (r *Exsss) Advance(n uint64) {
+	r.AdvanceBig(new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig advances the generator's state by n steps. If n is negative, the
+// state is moved backwards by -n steps.
+//
+func (r *Exsss) AdvanceBig(n *big.Int) {
+	var poly [2]uint64
+	copy(poly[:], exsCharPoly.XPow(n))
+	r.s0, r.s1 = jump(r.s0, r.s1, exsNext, &poly)
+}
+
+// Exrop encapsulates an Erlang exrop (xoroshiro116+) PRNG.
+//
+// The zero value is not a valid state; an Exrop must be seeded before use.
+//
+type Exrop struct {
+	s0, s1 uint64
+}
+
+// NewExrop returns a new Exrop seeded like rand:seed(exrop, seed).
+//
+func NewExrop(seed int64) *Exrop {
+	r := &Exrop{}
+	r.Seed(seed)
+	return r
+}
+
+// Seed seeds r like rand:seed(exrop, seed). The two state words are the first
+// two non-zero 58-bit outputs of a splitmix64 generator seeded with seed.
+//
+func (r *Exrop) Seed(seed int64) {
+	var x uint64
+	r.s0, x = seed58(uint64(seed))
+	r.s1, _ = seed58(x)
+}
+
+// SeedTuple seeds r like rand:seed(exrop, {a1, a2, a3}).
+//
+func (r *Exrop) SeedTuple(a1, a2, a3 int64) {
+	_, s1 := exropNext((uint64(a1)*4294967197+1)&mask58, (uint64(a2)*4294967231+1)&mask58)
+	r.s0, r.s1 = exropNext((uint64(a3)*4294967279+1)&mask58, s1)
+}
+
+// Next returns the next raw 58-bit output of the generator. Uniform and
+// UniformN are built on top of it.
+//
+func (r *Exrop) Next() uint64 {
+	v := (r.s0 + r.s1) & mask58
+	r.s0, r.s1 = exropNext(r.s0, r.s1)
+	return v
+}
+
+// Uniform returns a pseudo-random float64 in [0.0, 1.0), like rand:uniform().
+// The lowest bits of the output, of lower quality, are discarded.
+//
+func (r *Exrop) Uniform() float64 {
+	return float64(r.Next()>>5) * (1.0 / (1 << 53))
+}
+
+// UniformN returns a pseudo-random integer in [1, n], like rand:uniform(n).
+//
+// UniformN panics if n == 0.
+//
+func (r *Exrop) UniformN(n uint64) uint64 {
+	return uniformN(r.Next, n, 1)
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64, built from the top
+// bits of two successive 58-bit outputs. Erlang has no equivalent; it allows
+// the use of an Exrop as a math/rand.Source64.
+//
+func (r *Exrop) Uint64() uint64 {
+	hi := r.Next()
+	return hi<<6 | r.Next()>>52
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (r *Exrop) Int63() int64 {
+	return int64(r.Uint64() >> 1)
+}
+
+// Jump is equivalent to 2^64 calls to Next, like rand:jump(). It can be used
+// to generate 2^52 non-overlapping subsequences for parallel computations.
+//
+func (r *Exrop) Jump() {
+	r.s0, r.s1 = jump(r.s0, r.s1, exropNext, &exropJumpPoly)
+}
+
+// Advance advances the generator's state by n steps. It is equivalent to n
+// calls to Next.
+//
+func (r *Exrop) Advance(n uint64) {
+	r.AdvanceBig(new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig advances the generator's state by n steps. If n is negative, the
+// state is moved backwards by -n steps.
+//
+func (r *Exrop) AdvanceBig(n *big.Int) {
+	var poly [2]uint64
+	copy(poly[:], exropCharPoly.XPow(n))
+	r.s0, r.s1 = jump(r.s0, r.s1, exropNext, &poly)
+}
+
+// exsCharPoly and exropCharPoly are the rings of polynomials modulo the
+// characteristic polynomials of the xorshift116 and xoroshiro116 linear
+// engines.
+//
+var (
+	exsCharPoly   = gf2.NewMod(116, gf2.Poly{0x07044de228111dd9, 0x0005150c404c6494})
+	exropCharPoly = gf2.NewMod(116, gf2.Poly{0xfeec98f752640151, 0x0004110a8fa6d755})
+)
+
+// exsJumpPoly and exropJumpPoly are x^(2^64) modulo the characteristic
+// polynomials. These are the jump constants of rand.erl, which splits the
+// former into two 58-bit words.
+//
+var (
+	exsJumpPoly   = [2]uint64{0x302f8ea6bc32c797, 0x000d174a83e17de2}
+	exropJumpPoly = [2]uint64{0x4a11293241fcb12a, 0x0009863200f83fcd}
+)
+
+// exsNext returns the state following (s0, s1) for the xorshift116 linear
+// engine, with shifts 24, 11 and 41. The new first word is s1.
+//
+func exsNext(s0, s1 uint64) (uint64, uint64) {
+	s0 ^= s0 << 24 & mask58
+	return s1, s0 ^ s1 ^ s0>>11 ^ s1>>41
+}
+
+// exropNext returns the state following (s0, s1) for the xoroshiro116 linear
+// engine, with parameters 24, 2 and 35.
+//
+func exropNext(s0, s1 uint64) (uint64, uint64) {
+	s1 ^= s0
+	return (rotl58(s0, 24) ^ s1 ^ s1<<2) & mask58, rotl58(s1, 35)
+}
+
+func rotl58(x uint64, k uint) uint64 {
+	return (x<<k | x>>(58-k)) & mask58
+}
+
+// jump returns the state reached by applying the jump polynomial poly to
+// (s0, s1), next being the linear engine's transition function.
+//
+func jump(s0, s1 uint64, next func(uint64, uint64) (uint64, uint64), poly *[2]uint64) (uint64, uint64) {
+	var j0, j1 uint64
+	for i := 0; i < 116; i++ {
+		if poly[i/64]&(1<<uint(i%64)) != 0 {
+			j0 ^= s0
+			j1 ^= s1
+		}
+		s0, s1 = next(s0, s1)
+	}
+	return j0, j1
+}
+
+// seed58 returns the first non-zero 58-bit output of a splitmix64 generator
+// with state x, and the generator's new state.
+//
+func seed58(x uint64) (uint64, uint64) {
+	for {
+		x += 0x9E3779B97F4A7C15
+		z := x
+		z = (z ^ z>>30) * 0xBF58476D1CE4E5B9
+		z = (z ^ z>>27) * 0x94D049BB133111EB
+		if z = (z ^ z>>31) & mask58; z != 0 {
+			return z, x
+		}
+	}
+}
+
+// seed64 returns the first non-zero output of a splitmix64 generator with
+// state x, and the generator's new state.
+//
+func seed64(x uint64) (uint64, uint64) {
+	for {
+		x += 0x9E3779B97F4A7C15
+		z := x
+		z = (z ^ z>>30) * 0xBF58476D1CE4E5B9
+		z = (z ^ z>>27) * 0x94D049BB133111EB
+		if z ^= z >> 31; z != 0 {
+			return z, x
+		}
+	}
+}
+
+// uniformN implements rand:uniform(n), given a source of 58-bit values of
+// which the lowest weakLowBits bits are of lower quality.
+//
+func uniformN(next func() uint64, n uint64, weakLowBits uint) uint64 {
+	if n == 0 {
+		panic("erlrand: invalid argument to UniformN")
+	}
+	if n > 1<<58 {
+		return uniformRange(next, n, weakLowBits)
+	}
+	max := 1<<58 - n
+	for {
+		v := next()
+		if v < n {
+			return v + 1
+		}
+		i := v % n
+		if v-i <= max {
+			return i + 1
+		}
+	}
+}
+
+// uniformRange implements rand:uniform(n) for n > 2^58, like uniform_range in
+// rand.erl: two 58-bit values v and w are combined into the 115 or 116 bits
+// value (v with its weak low bits cleared) << (58 - weakLowBits) | w. Powers of
+// two use the low bits of that value, other ranges reject values from the
+// truncated top range.
+//
+func uniformRange(next func() uint64, n uint64, weakLowBits uint) uint64 {
+	shift := 58 - weakLowBits
+	// max = 2^(58+shift) - n
+	maxLo, b := bits.Sub64(0, n, 0)
+	maxHi := 1<<(shift-6) - b
+	for {
+		v := next() &^ (1<<weakLowBits - 1)
+		hi, lo := v>>(64-shift), v<<shift|next()
+		if n&(n-1) == 0 {
+			return lo&(n-1) + 1
+		}
+		// hi < 2^52 < n
+		_, i := bits.Div64(hi, lo, n)
+		lo, b = bits.Sub64(lo, i, 0)
+		hi -= b
+		if hi < maxHi || hi == maxHi && lo <= maxLo {
+			return i + 1
+		}
+	}
+}
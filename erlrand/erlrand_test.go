@@ -0,0 +1,172 @@
+package erlrand_test
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/db47h/rand64/v3/erlrand"
+)
+
+type source interface {
+	Next() uint64
+	Uniform() float64
+	UniformN(uint64) uint64
+	Jump()
+	Advance(uint64)
+	AdvanceBig(*big.Int)
+}
+
+// Regression values. The jump polynomials match the constants of rand.erl and
+// the exs1024s engine matches the reference xorshift1024* in C, which validates
+// the linear engines, but no Erlang reference output was available for the
+// seeding and scrambling functions, nor for UniformN with ranges larger than
+// 2^58. They should be checked against the reference values of OTP's
+// rand_SUITE.
+func TestSources(t *testing.T) {
+	tests := []struct {
+		name    string
+		rng     source
+		uniform []float64
+		n       []uint64
+		tuple   []uint64
+		jump    []uint64
+	}{
+		{"exsss", erlrand.NewExsss(42),
+			[]float64{0.3672301478324621, 0.899364294071664, 0.008882807305278462},
+			[]uint64{98, 71, 163304584700748458, 236311186574236831},
+			[]uint64{157246933823878026, 174071258560466640},
+			[]uint64{117447264493455284, 200921806439501384}},
+		{"exrop", erlrand.NewExrop(42),
+			[]float64{0.6944173855195852, 0.09519529252073555, 0.9731243498477494},
+			[]uint64{27, 60, 83580501712272439, 57427095004333060},
+			[]uint64{216123642555015413, 17759762793822134},
+			[]uint64{85987222949130674, 86097573486185211}},
+		{"exs1024s", erlrand.NewExs1024s(42),
+			[]float64{0.7076122897460778, 0.12712968251806833, 0.4116548957713666},
+			[]uint64{90, 20, 270754736054075527, 2840424013841742929},
+			[]uint64{1274231960519430157, 18101333842250248241},
+			[]uint64{17576538998066016441, 12347316960178982073}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.rng
+			for _, v := range tt.uniform {
+				if f := r.Uniform(); f != v {
+					t.Fatalf("Uniform: expected %v, got %v", v, f)
+				}
+			}
+			for i, n := range []uint64{100, 100, 1 << 58} {
+				if v := r.UniformN(n); v != tt.n[i] {
+					t.Fatalf("UniformN(%d): expected %d, got %d", n, tt.n[i], v)
+				}
+			}
+			if v := r.Next(); v != tt.n[3] {
+				t.Fatalf("Next: expected %d, got %d", tt.n[3], v)
+			}
+			switch r := r.(type) {
+			case *erlrand.Exsss:
+				r.SeedTuple(1, 2, 3)
+			case *erlrand.Exrop:
+				r.SeedTuple(1, 2, 3)
+			case *erlrand.Exs1024s:
+				r.SeedTuple(1, 2, 3)
+			}
+			for _, v := range tt.tuple {
+				if n := r.Next(); n != v {
+					t.Fatalf("SeedTuple: expected %d, got %d", v, n)
+				}
+			}
+			r.Jump()
+			for _, v := range tt.jump {
+				if n := r.Next(); n != v {
+					t.Fatalf("Jump: expected %d, got %d", v, n)
+				}
+			}
+		})
+	}
+}
+
+func TestAdvance(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		new  func() source
+		jump uint
+	}{
+		{"exsss", func() source { return erlrand.NewExsss(1234) }, 64},
+		{"exrop", func() source { return erlrand.NewExrop(1234) }, 64},
+		{"exs1024s", func() source { return erlrand.NewExs1024s(1234) }, 512},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, n := range []uint64{0, 1, 2, 115, 116, 117, 1000} {
+				r, s := tt.new(), tt.new()
+				for i := uint64(0); i < n; i++ {
+					r.Next()
+				}
+				s.Advance(n)
+				for i := 0; i < 3; i++ {
+					if v, w := s.Next(), r.Next(); v != w {
+						t.Fatalf("Advance(%d): expected %d, got %d", n, w, v)
+					}
+				}
+			}
+			// Jump is the same as AdvanceBig(2^jump)
+			r, s := tt.new(), tt.new()
+			r.Jump()
+			s.AdvanceBig(new(big.Int).Lsh(big.NewInt(1), tt.jump))
+			if v, w := s.Next(), r.Next(); v != w {
+				t.Fatalf("Jump: expected %d, got %d", w, v)
+			}
+			// and backwards
+			s.AdvanceBig(big.NewInt(-1))
+			s.AdvanceBig(new(big.Int).Lsh(big.NewInt(-1), tt.jump))
+			r = tt.new()
+			if v, w := s.Next(), r.Next(); v != w {
+				t.Fatalf("AdvanceBig(-2^%d): expected %d, got %d", tt.jump, w, v)
+			}
+		})
+	}
+}
+
+func TestUniformN(t *testing.T) {
+	for _, r := range []source{erlrand.NewExsss(7), erlrand.NewExrop(7), erlrand.NewExs1024s(7)} {
+		for _, n := range []uint64{1, 2, 6, 1000, 1<<58 - 1, 1 << 58, 1<<58 + 1, 1 << 60, 3 << 61, 1<<64 - 1} {
+			for i := 0; i < 1000; i++ {
+				if v := r.UniformN(n); v < 1 || v > n {
+					t.Fatalf("%T: UniformN(%d): got out of range value %d", r, n, v)
+				}
+			}
+		}
+	}
+	// the high bits of large ranges are not stuck
+	r := erlrand.NewExrop(7)
+	var or uint64
+	for i := 0; i < 100; i++ {
+		or |= r.UniformN(1<<64-1) - 1
+	}
+	if or != 1<<64-1 {
+		t.Fatalf("UniformN(2^64-1): some bits are never set: %#x", or)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("UniformN(0): expected panic")
+		}
+	}()
+	r.UniformN(0)
+}
+
+func ExampleExsss() {
+	// rand:seed(exsss, 42), then rand:uniform() three times and
+	// rand:uniform(100). Like TestSources, the output below is a regression
+	// value that has yet to be checked against a real erl.
+	r := erlrand.NewExsss(42)
+	for i := 0; i < 3; i++ {
+		fmt.Println(r.Uniform())
+	}
+	fmt.Println(r.UniformN(100))
+	// Output:
+	// 0.3672301478324621
+	// 0.899364294071664
+	// 0.008882807305278462
+	// 98
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package erlrand
+
+import (
+	"math/big"
+
+	"github.com/db47h/rand64/v3/internal/gf2"
+)
+
+// Exs1024s encapsulates an Erlang exs1024s (xorshift1024*) PRNG.
+//
+// Unlike exsss and exrop, exs1024s works on 64-bit words. Its state is a ring
+// of 16 words, which rand.erl stores as a pair of lists.
+//
+// Period: 2^1024-1. State size: 1024 bits.
+//
+// The zero value is not a valid state; an Exs1024s must be seeded before use.
+//
+type Exs1024s struct {
+	s [16]uint64
+	p int
+}
+
+// NewExs1024s returns a new Exs1024s seeded like rand:seed(exs1024s, seed).
+//
+func NewExs1024s(seed int64) *Exs1024s {
+	r := &Exs1024s{}
+	r.Seed(seed)
+	return r
+}
+
+// Seed seeds r like rand:seed(exs1024s, seed). The state words are the first
+// 16 non-zero outputs of a splitmix64 generator seeded with seed.
+//
+func (r *Exs1024s) Seed(seed int64) {
+	x := uint64(seed)
+	for i := range r.s {
+		r.s[i], x = seed64(x)
+	}
+	r.p = 0
+}
+
+// SeedTuple seeds r like rand:seed(exs1024s, {a1, a2, a3}). The state words
+// are the outputs of a xorshift64* generator, in reverse order.
+//
+func (r *Exs1024s) SeedTuple(a1, a2, a3 int64) {
+	const mask21 = 1<<21 - 1
+	b1 := (uint64(a1)&mask21 + 1) * 2097131 & mask21
+	b2 := (uint64(a2)&mask21 + 1) * 2097133 & mask21
+	b3 := (uint64(a3)&mask21 + 1) * 2097143 & mask21
+	x := b1<<43 | b2<<22 | b3<<1 | 1
+	for i := len(r.s) - 1; i >= 0; i-- {
+		x ^= x >> 12
+		x ^= x << 25
+		x ^= x >> 27
+		r.s[i] = x * 2685821657736338717
+	}
+	r.p = 0
+}
+
+// Next returns the next raw 64-bit output of the generator. Uniform and
+// UniformN are built on top of it.
+//
+func (r *Exs1024s) Next() uint64 {
+	s0 := r.s[r.p]
+	r.p = (r.p + 1) & 15
+	s1 := r.s[r.p]
+	s1 ^= s1 << 31
+	r.s[r.p] = s1 ^ s0 ^ s1>>11 ^ s0>>30
+	return r.s[r.p] * 1181783497276652981
+}
+
+// Uniform returns a pseudo-random float64 in [0.0, 1.0), like rand:uniform().
+//
+func (r *Exs1024s) Uniform() float64 {
+	return float64(r.Next()>>11) * (1.0 / (1 << 53))
+}
+
+// UniformN returns a pseudo-random integer in [1, n], like rand:uniform(n).
+//
+// UniformN panics if n == 0.
+//
+func (r *Exs1024s) UniformN(n uint64) uint64 {
+	if n == 0 {
+		panic("erlrand: invalid argument to UniformN")
+	}
+	max := -n // 2^64 - n
+	for {
+		v := r.Next()
+		if v < n {
+			return v + 1
+		}
+		i := v % n
+		if v-i <= max {
+			return i + 1
+		}
+	}
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64. It is the same as
+// Next.
+//
+func (r *Exs1024s) Uint64() uint64 {
+	return r.Next()
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (r *Exs1024s) Int63() int64 {
+	return int64(r.Next() >> 1)
+}
+
+// Jump is equivalent to 2^512 calls to Next, like rand:jump(). It can be used
+// to generate 2^512 non-overlapping subsequences for parallel computations.
+//
+func (r *Exs1024s) Jump() {
+	r.jump(&exs1024JumpPoly)
+}
+
+// Advance advances the generator's state by n steps. It is equivalent to n
+// calls to Next.
+//
+func (r *Exs1024s) Advance(n uint64) {
+	r.AdvanceBig(new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig advances the generator's state by n steps. If n is negative, the
+// state is moved backwards by -n steps.
+//
+func (r *Exs1024s) AdvanceBig(n *big.Int) {
+	var poly [16]uint64
+	copy(poly[:], exs1024CharPoly.XPow(n))
+	r.jump(&poly)
+}
+
+// jump applies the jump polynomial poly to the state of r.
+//
+func (r *Exs1024s) jump(poly *[16]uint64) {
+	var t [16]uint64
+	for i := 0; i < 1024; i++ {
+		if poly[i/64]&(1<<uint(i%64)) != 0 {
+			for j := range t {
+				t[j] ^= r.s[(j+r.p)&15]
+			}
+		}
+		r.Next()
+	}
+	for j := range t {
+		r.s[(j+r.p)&15] = t[j]
+	}
+}
+
+// exs1024CharPoly is the ring of polynomials modulo the characteristic
+// polynomial of the xorshift1024 linear engine.
+//
+var exs1024CharPoly = gf2.NewMod(1024, gf2.Poly{
+	0x1000000000000001, 0x2200aa001400f000, 0x0111e1c02bc18180, 0x030d535201556130,
+	0x4a32d044029b08f7, 0x34b3216457d7b028, 0xe860f083d70158c6, 0xdf6a7cadba32bca9,
+	0xbabab341e2554b59, 0xcd40a7e2537771ea, 0x0040f0e46e848800, 0xa1422cb7814f5c68,
+	0x53116c08605c805f, 0x0440024003007b28, 0x787878786d381540, 0x0000000000007879,
+})
+
+// exs1024JumpPoly is x^(2^512) modulo the characteristic polynomial. These are
+// the jump constants of rand.erl and of the reference xorshift1024*.
+//
+var exs1024JumpPoly = [16]uint64{
+	0x84242f96eca9c41d, 0xa3c65b8776f96855, 0x5b34a39f070b5837, 0x4489affce4f31a1e,
+	0x2ffeeb0a48316f40, 0xdc2d9891fe68c022, 0x3659132bb12fea70, 0xaac17d8efa43cab8,
+	0xc4cb815590989b13, 0x5ee975283d71c93b, 0x691548c86c1bd540, 0x7910c41d10a1e6a5,
+	0x0b5fc64563b3e2a8, 0x047f7684e9fc949d, 0xb99181f2d8f685ca, 0x284600e3f30e38c3,
+}
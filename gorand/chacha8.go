@@ -0,0 +1,235 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package gorand
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+const (
+	ctrInc = 4  // increment counter by 4 between block calls
+	ctrMax = 16 // reseed when counter reaches 16
+	chunk  = 32 // each chunk produced by block is 32 uint64s
+	reseed = 4  // reseed with 4 words
+)
+
+// ChaCha8 is the equivalent of math/rand/v2.ChaCha8, a cryptographically
+// strong generator based on the ChaCha8 stream cipher, as specified in
+// https://c2sp.org/chacha8rand.
+//
+// Each iteration uses a 32 bytes key to produce 992 bytes of output, plus the
+// 32 bytes of the key of the next iteration.
+//
+type ChaCha8 struct {
+	buf  [chunk]uint64
+	seed [4]uint64
+	i    uint32
+	n    uint32
+	c    uint32
+
+	// The last readLen bytes of readBuf are still to be consumed by Read.
+	readBuf [8]byte
+	readLen int
+}
+
+// NewChaCha8 returns a new ChaCha8 seeded like rand.NewChaCha8(seed).
+//
+func NewChaCha8(seed [32]byte) *ChaCha8 {
+	c := &ChaCha8{}
+	c.Seed(seed)
+	return c
+}
+
+// Seed resets the ChaCha8 to behave the same way as NewChaCha8(seed).
+//
+func (c *ChaCha8) Seed(seed [32]byte) {
+	for i := range c.seed {
+		c.seed[i] = binary.LittleEndian.Uint64(seed[i*8:])
+	}
+	chacha8Block(&c.seed, &c.buf, 0)
+	c.c = 0
+	c.i = 0
+	c.n = chunk
+	c.readLen = 0
+	c.readBuf = [8]byte{}
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (c *ChaCha8) Uint64() uint64 {
+	if c.i >= c.n {
+		c.refill()
+	}
+	x := c.buf[c.i]
+	c.i++
+	return x
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (c *ChaCha8) Int63() int64 {
+	return int64(c.Uint64() >> 1)
+}
+
+// Read reads exactly len(p) bytes into p. It always returns len(p) and a nil
+// error. Values returned by Uint64 are written in little-endian order.
+//
+// As with math/rand/v2.ChaCha8, interleaving calls to Read and Uint64 may
+// return bits generated before the last call to Uint64.
+//
+func (c *ChaCha8) Read(p []byte) (n int, err error) {
+	if c.readLen > 0 {
+		n = copy(p, c.readBuf[len(c.readBuf)-c.readLen:])
+		c.readLen -= n
+		p = p[n:]
+	}
+	for len(p) >= 8 {
+		binary.LittleEndian.PutUint64(p, c.Uint64())
+		p = p[8:]
+		n += 8
+	}
+	if len(p) > 0 {
+		binary.LittleEndian.PutUint64(c.readBuf[:], c.Uint64())
+		n += copy(p, c.readBuf[:])
+		c.readLen = 8 - len(p)
+	}
+	return
+}
+
+// refill generates the next 32 values. Every ctrMax/ctrInc blocks, the last 4
+// values are used as the new key instead of being returned. This is done
+// right before computing the next block so that the marshaled state is just
+// the key and the number of values already used.
+//
+func (c *ChaCha8) refill() {
+	c.c += ctrInc
+	if c.c == ctrMax {
+		copy(c.seed[:], c.buf[chunk-reseed:])
+		c.c = 0
+	}
+	chacha8Block(&c.seed, &c.buf, c.c)
+	c.i = 0
+	c.n = chunk
+	if c.c == ctrMax-ctrInc {
+		c.n = chunk - reseed
+	}
+}
+
+// AppendBinary appends the binary representation of c to b, in the same
+// format as MarshalBinary.
+//
+func (c *ChaCha8) AppendBinary(b []byte) ([]byte, error) {
+	if c.readLen > 0 {
+		b = append(b, "readbuf:"...)
+		b = append(b, uint8(c.readLen))
+		b = append(b, c.readBuf[len(c.readBuf)-c.readLen:]...)
+	}
+	var data [6 * 8]byte
+	copy(data[:], "chacha8:")
+	used := (c.c/ctrInc)*chunk + c.i
+	binary.BigEndian.PutUint64(data[1*8:], uint64(used))
+	for i, s := range c.seed {
+		binary.LittleEndian.PutUint64(data[(2+i)*8:], s)
+	}
+	return append(b, data[:]...), nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The format
+// is the same as the one of math/rand/v2.ChaCha8.
+//
+func (c *ChaCha8) MarshalBinary() ([]byte, error) {
+	return c.AppendBinary(make([]byte, 0, 64))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// accepts the output of MarshalBinary for a math/rand/v2.ChaCha8.
+//
+func (c *ChaCha8) UnmarshalBinary(data []byte) error {
+	errInvalid := errors.New("gorand: invalid ChaCha8 encoding")
+	readLen := 0
+	var readBuf [8]byte
+	if len(data) >= 8 && string(data[:8]) == "readbuf:" {
+		data = data[8:]
+		if len(data) == 0 || data[0] > 8 || len(data) < int(1+data[0]) {
+			return errInvalid
+		}
+		readLen = copy(readBuf[len(readBuf)-int(data[0]):], data[1:1+data[0]])
+		data = data[1+data[0]:]
+	}
+	if len(data) != 6*8 || string(data[:8]) != "chacha8:" {
+		return errInvalid
+	}
+	used := binary.BigEndian.Uint64(data[1*8:])
+	if used > (ctrMax/ctrInc)*chunk-reseed {
+		return errInvalid
+	}
+	for i := range c.seed {
+		c.seed[i] = binary.LittleEndian.Uint64(data[(2+i)*8:])
+	}
+	c.c = ctrInc * (uint32(used) / chunk)
+	chacha8Block(&c.seed, &c.buf, c.c)
+	c.i = uint32(used) % chunk
+	c.n = chunk
+	if c.c == ctrMax-ctrInc {
+		c.n = chunk - reseed
+	}
+	c.readBuf, c.readLen = readBuf, readLen
+	return nil
+}
+
+// chacha8Block computes 4 ChaCha8 blocks with counters counter to counter+3
+// and the key seed, and stores them into buf, interlaced 32 bits at a time
+// like the SIMD implementations of math/rand/v2 do. As in chacha8rand, the
+// constants and counters are not added back to the output.
+//
+func chacha8Block(seed *[4]uint64, buf *[chunk]uint64, counter uint32) {
+	var x [4][16]uint32
+	for i := range x {
+		b := &x[i]
+		b[0], b[1], b[2], b[3] = 0x61707865, 0x3320646e, 0x79622d32, 0x6b206574
+		for j, s := range seed {
+			b[4+2*j], b[5+2*j] = uint32(s), uint32(s>>32)
+		}
+		b[12] = counter + uint32(i)
+
+		k := *b
+		for round := 0; round < 4; round++ {
+			qr(b, 0, 4, 8, 12)
+			qr(b, 1, 5, 9, 13)
+			qr(b, 2, 6, 10, 14)
+			qr(b, 3, 7, 11, 15)
+
+			qr(b, 0, 5, 10, 15)
+			qr(b, 1, 6, 11, 12)
+			qr(b, 2, 7, 8, 13)
+			qr(b, 3, 4, 9, 14)
+		}
+		for j := 4; j < 12; j++ {
+			b[j] += k[j]
+		}
+	}
+	for r := 0; r < 16; r++ {
+		buf[2*r] = uint64(x[0][r]) | uint64(x[1][r])<<32
+		buf[2*r+1] = uint64(x[2][r]) | uint64(x[3][r])<<32
+	}
+}
+
+// qr is the ChaCha quarter round on words a, b, c and d of x.
+//
+func qr(x *[16]uint32, a, b, c, d int) {
+	x[a] += x[b]
+	x[d] ^= x[a]
+	x[d] = x[d]<<16 | x[d]>>16
+	x[c] += x[d]
+	x[b] ^= x[c]
+	x[b] = x[b]<<12 | x[b]>>20
+	x[a] += x[b]
+	x[d] ^= x[a]
+	x[d] = x[d]<<8 | x[d]>>24
+	x[c] += x[d]
+	x[b] ^= x[c]
+	x[b] = x[b]<<7 | x[b]>>25
+}
@@ -0,0 +1,28 @@
+package gorand
+
+import "testing"
+
+// TestCooked checks rngCooked against the procedure of gen_cooked.go in Go's
+// math/rand, which runs the generator for 7.8e12 steps.
+func TestCooked(t *testing.T) {
+	var rng Rng
+	x := int32(1)
+	for i := -20; i < rngLen; i++ {
+		x = seedrand(x)
+		if i >= 0 {
+			var u int64
+			u = int64(x) << 20
+			x = seedrand(x)
+			u ^= int64(x) << 10
+			x = seedrand(x)
+			u ^= int64(x)
+			rng.Vec[i] = uint64(u)
+		}
+	}
+	rng.Advance(7.8e12)
+	for i := range rngCooked {
+		if v := int64(rng.Vec[i]); v != rngCooked[i] {
+			t.Fatalf("rngCooked[%d]: expected %d, got %d", i, rngCooked[i], v)
+		}
+	}
+}
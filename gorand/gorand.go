@@ -0,0 +1,272 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+/*
+Package gorand provides sources of pseudo-random numbers that are bit-exact
+compatible with the ones of Go's math/rand and math/rand/v2 packages.
+
+Rng is the source returned by rand.NewSource in math/rand. This is an additive
+lagged Fibonacci generator, x[n] = x[n-607] + x[n-273] mod 2^64, by D.P.
+Mitchell and J.A. Reeds, seeded with a Lehmer generator whose output is XORed
+with a precomputed ("cooked") table. Its state size is 607 64-bit words.
+
+PCG and ChaCha8 are the sources of math/rand/v2. Given the same seed, the
+sources produce the same values as their standard library counterparts:
+
+	Go standard library              gorand
+	rand.NewSource(seed)             gorand.New(seed)
+	rand.New(rand.NewSource(s))      rand.New(gorand.New(s))
+	randv2.NewPCG(seed1, seed2)      gorand.NewPCG(seed1, seed2)
+	randv2.NewChaCha8(seed)          gorand.NewChaCha8(seed)
+
+Unlike the math/rand source, the state of a Rng is exported, can be marshaled
+with MarshalBinary or any other encoding of its fields, and can be advanced by
+an arbitrary number of steps. This allows checkpointing a long run, or resuming
+a run known to have drawn n values from rand.NewSource(seed).
+
+PCG and ChaCha8 use the same binary marshaling format as math/rand/v2, so
+that a state can be saved by one implementation and restored by the other.
+
+Use of this algorithm is governed by a BSD-style license that can be found in
+the LICENSE-go file.
+*/
+package gorand
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math/bits"
+)
+
+const (
+	rngLen   = 607
+	rngTap   = 273
+	rngMask  = 1<<63 - 1
+	int32max = 1<<31 - 1
+)
+
+// Rng encapsulates the state of a Go math/rand source.
+//
+// The zero value only returns zeros; use New or Seed before use.
+//
+type Rng struct {
+	// Tap is the index of the lagged value added to the oldest value of Vec.
+	// The oldest value is at index (Tap + 333) % 607, and values get newer
+	// as the index decreases. Tap must be in the range [0, 607):
+	// UnmarshalBinary and UnmarshalJSON reject other values, but a Rng
+	// decoded or set by other means must be checked by the caller, otherwise
+	// Uint64 panics.
+	Tap int
+	// Vec holds the last 607 values of the sequence.
+	Vec [rngLen]uint64
+}
+
+// New returns a new Rng seeded like rand.NewSource(seed).
+//
+func New(seed int64) *Rng {
+	rng := &Rng{}
+	rng.Seed(seed)
+	return rng
+}
+
+// Seed uses the provided seed value to initialize the generator to a
+// deterministic state, like the Seed method of a math/rand source. Only the
+// value of seed modulo 2^31-1 is significant, and seeds 0 and 89482311 are
+// equivalent.
+//
+func (rng *Rng) Seed(seed int64) {
+	rng.Tap = 0
+
+	seed = seed % int32max
+	if seed < 0 {
+		seed += int32max
+	}
+	if seed == 0 {
+		seed = 89482311
+	}
+
+	x := int32(seed)
+	for i := -20; i < rngLen; i++ {
+		x = seedrand(x)
+		if i >= 0 {
+			var u int64
+			u = int64(x) << 40
+			x = seedrand(x)
+			u ^= int64(x) << 20
+			x = seedrand(x)
+			u ^= int64(x)
+			u ^= rngCooked[i]
+			rng.Vec[i] = uint64(u)
+		}
+	}
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (rng *Rng) Uint64() uint64 {
+	feed := rng.oldest()
+	rng.Tap--
+	if rng.Tap < 0 {
+		rng.Tap += rngLen
+	}
+	x := rng.Vec[feed] + rng.Vec[rng.Tap]
+	rng.Vec[feed] = x
+	return x
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (rng *Rng) Int63() int64 {
+	return int64(rng.Uint64() & rngMask)
+}
+
+// Advance advances the generator's state by n steps. It is equivalent to n
+// calls to Uint64, but runs in O(log n) time.
+//
+func (rng *Rng) Advance(n uint64) {
+	if n == 0 {
+		return
+	}
+	// z[k] is the k-th value of the sequence, starting from the oldest
+	// value in Vec, extended by the recurrence.
+	var z [2*rngLen - 1]uint64
+	old := rng.oldest()
+	for k := 0; k < rngLen; k++ {
+		z[k] = rng.Vec[(old+rngLen-k)%rngLen]
+	}
+	for k := rngLen; k < len(z); k++ {
+		z[k] = z[k-rngLen] + z[k-rngTap]
+	}
+	// with x^n = sum r_i x^i mod the characteristic polynomial, z[n+k] =
+	// sum r_i z[i+k].
+	r := xPow(n)
+	rng.Tap = (rng.Tap + rngLen - int(n%rngLen)) % rngLen
+	old = rng.oldest()
+	for k := 0; k < rngLen; k++ {
+		var v uint64
+		for i, c := range r {
+			v += c * z[i+k]
+		}
+		rng.Vec[(old+rngLen-k)%rngLen] = v
+	}
+}
+
+// oldest returns the index of the oldest value in Vec, the one that will be
+// overwritten by the next call to Uint64.
+//
+func (rng *Rng) oldest() int {
+	return (rng.Tap + rngLen - rngTap - 1) % rngLen
+}
+
+const marshalPrefix = "gorand:"
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The
+// encoded state is the prefix "gorand:" followed by Tap and Vec as big-endian
+// 64-bit integers.
+//
+func (rng *Rng) MarshalBinary() ([]byte, error) {
+	b := make([]byte, len(marshalPrefix)+8*(1+rngLen))
+	p := b[copy(b, marshalPrefix):]
+	binary.BigEndian.PutUint64(p, uint64(rng.Tap))
+	for i, v := range rng.Vec {
+		binary.BigEndian.PutUint64(p[8*(i+1):], v)
+	}
+	return b, nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+//
+func (rng *Rng) UnmarshalBinary(data []byte) error {
+	if len(data) != len(marshalPrefix)+8*(1+rngLen) || string(data[:len(marshalPrefix)]) != marshalPrefix {
+		return errors.New("gorand: invalid Rng encoding")
+	}
+	p := data[len(marshalPrefix):]
+	tap := binary.BigEndian.Uint64(p)
+	if tap >= rngLen {
+		return errors.New("gorand: invalid Rng encoding")
+	}
+	rng.Tap = int(tap)
+	for i := range rng.Vec {
+		rng.Vec[i] = binary.BigEndian.Uint64(p[8*(i+1):])
+	}
+	return nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. It decodes the
+// exported fields of rng like the default JSON decoding would, and returns an
+// error if Tap is out of range.
+//
+func (rng *Rng) UnmarshalJSON(data []byte) error {
+	type state Rng // no UnmarshalJSON method
+	s := state(*rng)
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if uint(s.Tap) >= rngLen {
+		return errors.New("gorand: Rng Tap out of range")
+	}
+	*rng = Rng(s)
+	return nil
+}
+
+// seedrand returns the next value of the Lehmer generator x[n+1] = 48271 *
+// x[n] mod (2^31 - 1), computed with Schrage's method.
+//
+func seedrand(x int32) int32 {
+	const (
+		A = 48271
+		Q = 44488
+		R = 3399
+	)
+
+	hi := x / Q
+	lo := x % Q
+	x = A*lo - R*hi
+	if x < 0 {
+		x += int32max
+	}
+	return x
+}
+
+// xPow returns the coefficients of x^n modulo the characteristic polynomial
+// of the generator, x^607 - x^334 - 1, over the integers modulo 2^64.
+//
+func xPow(n uint64) []uint64 {
+	r := make([]uint64, rngLen)
+	r[0] = 1
+	for i := 63 - bits.LeadingZeros64(n); i >= 0; i-- {
+		r = polyMulMod(r, r)
+		if n&(1<<uint(i)) != 0 {
+			// multiply by x
+			c := r[rngLen-1]
+			copy(r[1:], r[:rngLen-1])
+			r[0] = c
+			r[rngLen-rngTap] += c
+		}
+	}
+	return r
+}
+
+// polyMulMod returns a*b modulo x^607 - x^334 - 1.
+//
+func polyMulMod(a, b []uint64) []uint64 {
+	var t [2*rngLen - 1]uint64
+	for i, c := range a {
+		if c == 0 {
+			continue
+		}
+		for j, d := range b {
+			t[i+j] += c * d
+		}
+	}
+	// x^d = x^(d-273) + x^(d-607)
+	for d := len(t) - 1; d >= rngLen; d-- {
+		t[d-rngTap] += t[d]
+		t[d-rngLen] += t[d]
+	}
+	r := make([]uint64, rngLen)
+	copy(r, t[:rngLen])
+	return r
+}
@@ -0,0 +1,166 @@
+package gorand_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/db47h/rand64/v3/gorand"
+)
+
+var seeds = []int64{0, 1, 42, -1, 89482311, 1<<31 - 1, -1 << 63, 1<<63 - 1, 1387366483214}
+
+func TestRng(t *testing.T) {
+	for _, seed := range seeds {
+		src := rand.NewSource(seed).(rand.Source64)
+		rng := gorand.New(seed)
+		for i := 0; i < 2000; i++ {
+			if v, w := rng.Uint64(), src.Uint64(); v != w {
+				t.Fatalf("seed %d: value %d: expected %d, got %d", seed, i, w, v)
+			}
+			if v, w := rng.Int63(), src.Int63(); v != w {
+				t.Fatalf("seed %d: Int63 %d: expected %d, got %d", seed, i, w, v)
+			}
+		}
+	}
+}
+
+func TestRng_Rand(t *testing.T) {
+	r0 := rand.New(rand.NewSource(42))
+	r1 := rand.New(gorand.New(42))
+	for i := 0; i < 100; i++ {
+		if v, w := r1.Intn(100), r0.Intn(100); v != w {
+			t.Fatalf("Intn: expected %d, got %d", w, v)
+		}
+		if v, w := r1.Float64(), r0.Float64(); v != w {
+			t.Fatalf("Float64: expected %v, got %v", w, v)
+		}
+		if v, w := r1.NormFloat64(), r0.NormFloat64(); v != w {
+			t.Fatalf("NormFloat64: expected %v, got %v", w, v)
+		}
+	}
+	p0, p1 := r0.Perm(20), r1.Perm(20)
+	for i := range p0 {
+		if p0[i] != p1[i] {
+			t.Fatalf("Perm: expected %v, got %v", p0, p1)
+		}
+	}
+}
+
+func TestRng_Advance(t *testing.T) {
+	for _, n := range []uint64{0, 1, 272, 273, 606, 607, 608, 1000, 5000} {
+		src := rand.NewSource(7).(rand.Source64)
+		for i := uint64(0); i < n; i++ {
+			src.Uint64()
+		}
+		rng := gorand.New(7)
+		rng.Advance(n)
+		for i := 0; i < 1000; i++ {
+			if v, w := rng.Uint64(), src.Uint64(); v != w {
+				t.Fatalf("Advance(%d): value %d: expected %d, got %d", n, i, w, v)
+			}
+		}
+	}
+	// Advance from an arbitrary position
+	r0, r1 := gorand.New(7), gorand.New(7)
+	for i := 0; i < 1234; i++ {
+		r0.Uint64()
+	}
+	r1.Advance(1000)
+	r1.Advance(234)
+	if *r0 != *r1 {
+		t.Fatal("Advance(1000) + Advance(234): states differ from 1234 steps")
+	}
+}
+
+func TestRng_Marshal(t *testing.T) {
+	src := rand.NewSource(42).(rand.Source64)
+	rng := gorand.New(42)
+	for i := 0; i < 1000; i++ {
+		src.Uint64()
+		rng.Uint64()
+	}
+	b, err := rng.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(b, []byte("gorand:")) {
+		t.Fatalf("MarshalBinary: missing prefix in %q", b[:16])
+	}
+	var r gorand.Rng
+	if err = r.UnmarshalBinary(b); err != nil {
+		t.Fatal(err)
+	}
+	if r != *rng {
+		t.Fatal("UnmarshalBinary: state differs")
+	}
+	for i := 0; i < 1000; i++ {
+		if v, w := r.Uint64(), src.Uint64(); v != w {
+			t.Fatalf("value %d after UnmarshalBinary: expected %d, got %d", i, w, v)
+		}
+	}
+
+	// exported fields
+	j, err := json.Marshal(rng)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r = gorand.Rng{}
+	if err = json.Unmarshal(j, &r); err != nil {
+		t.Fatal(err)
+	}
+	if r != *rng {
+		t.Fatal("json: state differs")
+	}
+	for _, tap := range []int{-1, 607} {
+		if err = json.Unmarshal([]byte(fmt.Sprintf(`{"Tap":%d}`, tap)), &r); err == nil {
+			t.Fatalf("json: expected error for Tap %d", tap)
+		}
+	}
+	if r != *rng {
+		t.Fatal("json: state changed by invalid data")
+	}
+
+	// invalid data
+	for i, d := range [][]byte{nil, b[:len(b)-1], append([]byte("gorand?"), b[7:]...)} {
+		if err = r.UnmarshalBinary(d); err == nil {
+			t.Fatalf("UnmarshalBinary: expected error for invalid data #%d", i)
+		}
+	}
+	b[7] = 0xff // Tap out of range
+	if err = r.UnmarshalBinary(b); err == nil {
+		t.Fatal("UnmarshalBinary: expected error for out of range Tap")
+	}
+}
+
+// Expected values from the tests of math/rand/v2 and internal/chacha8rand.
+func TestPCG(t *testing.T) {
+	p := gorand.NewPCG(1, 2)
+	for i, w := range []uint64{0xc4f5a58656eef510, 0x9dcec3ad077dec6c, 0xc8d04605312f8088, 0xcbedc0dcb63ac19a} {
+		if v := p.Uint64(); v != w {
+			t.Fatalf("value %d: expected %#x, got %#x", i, w, v)
+		}
+	}
+	b, _ := gorand.NewPCG(1, 2).MarshalBinary()
+	if want := "pcg:\x00\x00\x00\x00\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00\x02"; string(b) != want {
+		t.Fatalf("MarshalBinary: expected %q, got %q", want, b)
+	}
+}
+
+func TestChaCha8(t *testing.T) {
+	var seed [32]byte
+	copy(seed[:], "ABCDEFGHIJKLMNOPQRSTUVWXYZ123456")
+	want := map[int]uint64{
+		0: 0xb773b6063d4616a5, 1: 0x1160af22a66abc3c, 2: 0x8c2599d9418d287c, 3: 0x7ee07e037edc5cd6,
+		123: 0x861d6c139c06c871, 124: 0x5f41df72e05e0487, 125: 0x25bd7e1e1ae26b1d, 371: 0xddd9c6d34bffa11f,
+	}
+	c := gorand.NewChaCha8(seed)
+	for i := 0; i < 372; i++ {
+		v := c.Uint64()
+		if w, ok := want[i]; ok && v != w {
+			t.Fatalf("value %d: expected %#x, got %#x", i, w, v)
+		}
+	}
+}
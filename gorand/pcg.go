@@ -0,0 +1,98 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package gorand
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// PCG is the equivalent of math/rand/v2.PCG, a PCG generator with 128 bits of
+// state, a full 128-bit LCG multiplier, a fixed increment and the DXSM output
+// function applied to the new state. This is not the same generator as
+// pcg.RngDXSM, which uses a 64-bit multiplier and the state before the step.
+//
+// The zero value PCG{} is equivalent to NewPCG(0, 0).
+//
+type PCG struct {
+	Hi uint64 // high 64 bits of 128 bits state
+	Lo uint64 // low 64 bits of 128 bits state
+}
+
+// NewPCG returns a new PCG seeded like rand.NewPCG(seed1, seed2).
+//
+func NewPCG(seed1, seed2 uint64) *PCG {
+	return &PCG{seed1, seed2}
+}
+
+// Seed resets the PCG to behave the same way as NewPCG(seed1, seed2).
+//
+func (p *PCG) Seed(seed1, seed2 uint64) {
+	p.Hi = seed1
+	p.Lo = seed2
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (p *PCG) Uint64() uint64 {
+	const (
+		mulHi    = 2549297995355413924
+		mulLo    = 4865540595714422341
+		incHi    = 6364136223846793005
+		incLo    = 1442695040888963407
+		cheapMul = 0xda942042e4dd58b5
+	)
+
+	hi, lo := bits.Mul64(p.Lo, mulLo)
+	hi += p.Hi*mulLo + p.Lo*mulHi
+	lo, c := bits.Add64(lo, incLo, 0)
+	hi, _ = bits.Add64(hi, incHi, c)
+	p.Lo = lo
+	p.Hi = hi
+
+	// DXSM
+	hi ^= hi >> 32
+	hi *= cheapMul
+	hi ^= hi >> 48
+	hi *= lo | 1
+	return hi
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (p *PCG) Int63() int64 {
+	return int64(p.Uint64() >> 1)
+}
+
+// AppendBinary appends the binary representation of p to b, in the same
+// format as MarshalBinary.
+//
+func (p *PCG) AppendBinary(b []byte) ([]byte, error) {
+	var buf [16]byte
+	binary.BigEndian.PutUint64(buf[:], p.Hi)
+	binary.BigEndian.PutUint64(buf[8:], p.Lo)
+	b = append(b, "pcg:"...)
+	return append(b, buf[:]...), nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface. The format
+// is the same as the one of math/rand/v2.PCG.
+//
+func (p *PCG) MarshalBinary() ([]byte, error) {
+	return p.AppendBinary(make([]byte, 0, 20))
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface. It
+// accepts the output of MarshalBinary for a math/rand/v2.PCG.
+//
+func (p *PCG) UnmarshalBinary(data []byte) error {
+	if len(data) != 20 || string(data[:4]) != "pcg:" {
+		return errors.New("gorand: invalid PCG encoding")
+	}
+	p.Hi = binary.BigEndian.Uint64(data[4:])
+	p.Lo = binary.BigEndian.Uint64(data[4+8:])
+	return nil
+}
@@ -0,0 +1,109 @@
+//go:build go1.22
+// +build go1.22
+
+package gorand_test
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/db47h/rand64/v3/gorand"
+)
+
+func TestPCG_v2(t *testing.T) {
+	for _, s := range [][2]uint64{{0, 0}, {1, 2}, {^uint64(0), 42}} {
+		p0 := rand.NewPCG(s[0], s[1])
+		p1 := gorand.NewPCG(s[0], s[1])
+		for i := 0; i < 1000; i++ {
+			if v, w := p1.Uint64(), p0.Uint64(); v != w {
+				t.Fatalf("NewPCG(%d, %d): value %d: expected %d, got %d", s[0], s[1], i, w, v)
+			}
+		}
+		b0, _ := p0.MarshalBinary()
+		b1, _ := p1.MarshalBinary()
+		if !bytes.Equal(b0, b1) {
+			t.Fatalf("MarshalBinary: expected %x, got %x", b0, b1)
+		}
+		// state transfer in both directions
+		p0.Uint64()
+		b0, _ = p0.MarshalBinary()
+		if err := p1.UnmarshalBinary(b0); err != nil {
+			t.Fatal(err)
+		}
+		if v, w := p1.Uint64(), p0.Uint64(); v != w {
+			t.Fatalf("after UnmarshalBinary: expected %d, got %d", w, v)
+		}
+		b1, _ = p1.MarshalBinary()
+		if err := p0.UnmarshalBinary(b1); err != nil {
+			t.Fatal(err)
+		}
+		if v, w := p1.Uint64(), p0.Uint64(); v != w {
+			t.Fatalf("after v2 UnmarshalBinary: expected %d, got %d", w, v)
+		}
+	}
+	var p gorand.PCG
+	if err := p.UnmarshalBinary([]byte("pcg:0123456789abcde")); err == nil {
+		t.Fatal("UnmarshalBinary: expected error")
+	}
+}
+
+func TestChaCha8_v2(t *testing.T) {
+	seed := [32]byte([]byte("chacha8 seed for rand64 testing!"))
+	c0 := rand.NewChaCha8(seed)
+	c1 := gorand.NewChaCha8(seed)
+	// long enough to cover several reseeds
+	for i := 0; i < 1000; i++ {
+		if v, w := c1.Uint64(), c0.Uint64(); v != w {
+			t.Fatalf("value %d: expected %d, got %d", i, w, v)
+		}
+		if i%37 == 0 {
+			b0, _ := c0.MarshalBinary()
+			b1, _ := c1.MarshalBinary()
+			if !bytes.Equal(b0, b1) {
+				t.Fatalf("MarshalBinary after %d values: expected %x, got %x", i+1, b0, b1)
+			}
+		}
+	}
+
+	// Read and partial reads
+	var r0, r1 [21]byte
+	for i := 0; i < 10; i++ {
+		n := i % len(r0)
+		c0.Read(r0[:n])
+		c1.Read(r1[:n])
+		if r0 != r1 {
+			t.Fatalf("Read(%d): expected %x, got %x", n, r0[:n], r1[:n])
+		}
+		b0, _ := c0.MarshalBinary()
+		b1, _ := c1.MarshalBinary()
+		if !bytes.Equal(b0, b1) {
+			t.Fatalf("MarshalBinary after Read(%d): expected %q, got %q", n, b0, b1)
+		}
+	}
+
+	// state transfer in both directions, with a pending read buffer
+	c0.Read(r0[:3])
+	b0, _ := c0.MarshalBinary()
+	var c gorand.ChaCha8
+	if err := c.UnmarshalBinary(b0); err != nil {
+		t.Fatal(err)
+	}
+	c0.Read(r0[:])
+	c.Read(r1[:])
+	if r0 != r1 {
+		t.Fatalf("Read after UnmarshalBinary: expected %x, got %x", r0, r1)
+	}
+	for i := 0; i < 200; i++ {
+		c.Uint64()
+	}
+	b1, _ := c.MarshalBinary()
+	if err := c0.UnmarshalBinary(b1); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 200; i++ {
+		if v, w := c.Uint64(), c0.Uint64(); v != w {
+			t.Fatalf("after v2 UnmarshalBinary: value %d: expected %d, got %d", i, w, v)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+/*
+Package gf2 implements the arithmetic on polynomials over GF(2) needed to jump
+F2-linear generators ahead by an arbitrary number of steps.
+
+If T is the state transition matrix of such a generator and p(x) its
+characteristic polynomial, then by the Cayley-Hamilton theorem, T^n = q(T) where
+q(x) = x^n mod p(x). Computing q takes O(log n) polynomial products, after
+which applying q to the state requires deg(p) state transitions.
+*/
+package gf2
+
+import (
+	"math/big"
+)
+
+// A Poly is a polynomial over GF(2). Bit i%64 of Poly[i/64] is the
+// coefficient of x^i.
+//
+type Poly []uint64
+
+// Mod represents the ring of polynomials modulo a primitive polynomial p(x) of
+// degree n.
+//
+type Mod struct {
+	n      int
+	low    Poly     // p(x) - x^n
+	period *big.Int // order of x in the ring: 2^n-1
+	// exps lists the exponents of the terms of low when low is sparse and of
+	// degree lower than n-64, in which case reduction can be done 64 bits at a
+	// time.
+	exps []int
+}
+
+// NewMod returns a new Mod for the primitive polynomial x^n + low(x).
+//
+func NewMod(n int, low Poly) *Mod {
+	w := words(n)
+	if len(low) > w {
+		panic("gf2: degree of low part must be lower than n")
+	}
+	m := &Mod{n: n, low: make(Poly, w)}
+	copy(m.low, low)
+	m.period = new(big.Int).Lsh(big.NewInt(1), uint(n))
+	m.period.Sub(m.period, big.NewInt(1))
+	var exps []int
+	for i := 0; i < n; i++ {
+		if m.low[i/64]>>uint(i%64)&1 != 0 {
+			exps = append(exps, i)
+		}
+	}
+	if len(exps) > 0 && len(exps) < w*8 && exps[len(exps)-1] < n-64 {
+		m.exps = exps
+	}
+	return m
+}
+
+// XPow returns x^e mod p(x). Negative exponents are accepted: since p is
+// primitive, x^e = x^(e mod 2^n-1).
+//
+func (m *Mod) XPow(e *big.Int) Poly {
+	if e.Sign() < 0 || e.BitLen() > m.n {
+		e = new(big.Int).Mod(e, m.period)
+	}
+	w := words(m.n)
+	r := make(Poly, w)
+	r[0] = 1
+	t := make(Poly, 2*w)
+	for i := e.BitLen() - 1; i >= 0; i-- {
+		m.square(r, t)
+		if e.Bit(i) != 0 {
+			m.mulX(r)
+		}
+	}
+	return r
+}
+
+// mulX sets r to r*x mod p.
+//
+func (m *Mod) mulX(r Poly) {
+	var c uint64
+	for i := range r {
+		r[i], c = r[i]<<1|c, r[i]>>63
+	}
+	hi := m.n / 64
+	sh := uint(m.n % 64)
+	if sh == 0 {
+		// x^n overflowed from the top word
+		if c == 0 {
+			return
+		}
+	} else {
+		if r[hi]>>sh&1 == 0 {
+			return
+		}
+		r[hi] &^= 1 << sh
+	}
+	for i, v := range m.low {
+		r[i] ^= v
+	}
+}
+
+// square sets r to r^2 mod p. t is scratch space of length 2*len(r).
+//
+func (m *Mod) square(r, t Poly) {
+	// over GF(2), (sum a_i x^i)^2 = sum a_i x^2i
+	for i, v := range r {
+		t[2*i] = spread(uint32(v))
+		t[2*i+1] = spread(uint32(v >> 32))
+	}
+	m.reduce(t)
+	copy(r, t)
+}
+
+// reduce reduces t modulo p in place. t must have a degree lower than 2n-1.
+//
+func (m *Mod) reduce(t Poly) {
+	if m.exps != nil {
+		m.reduceSparse(t)
+		return
+	}
+	for k := 2*m.n - 2; k >= m.n; k-- {
+		if t[k/64]>>uint(k%64)&1 == 0 {
+			continue
+		}
+		t[k/64] &^= 1 << uint(k%64)
+		// t ^= low << (k-n)
+		s := k - m.n
+		ws, bs := s/64, uint(s%64)
+		if bs == 0 {
+			for i, v := range m.low {
+				t[ws+i] ^= v
+			}
+			continue
+		}
+		var c uint64
+		for i, v := range m.low {
+			t[ws+i] ^= v<<bs | c
+			c = v >> (64 - bs)
+		}
+		if c != 0 {
+			t[ws+len(m.low)] ^= c
+		}
+	}
+}
+
+// reduceSparse is like reduce, but reduces 64 terms at a time: for each
+// 64-bit chunk c of t above x^n, c*x^k is replaced by c*x^(k-n)*low(x).
+// Since deg(low) < n-64, the new terms are all below the chunk.
+//
+func (m *Mod) reduceSparse(t Poly) {
+	for hi := 2*m.n - 2; hi >= m.n; hi -= 64 {
+		lo := hi - 63
+		if lo < m.n {
+			lo = m.n
+		}
+		c := getBits(t, lo, hi-lo+1)
+		if c == 0 {
+			continue
+		}
+		xorBits(t, lo, c) // clear chunk
+		for _, e := range m.exps {
+			xorBits(t, lo-m.n+e, c)
+		}
+	}
+}
+
+// getBits returns the n bits of t starting at bit pos. n must be in [1, 64].
+//
+func getBits(t Poly, pos, n int) uint64 {
+	w, b := pos/64, uint(pos%64)
+	v := t[w] >> b
+	if b != 0 && w+1 < len(t) {
+		v |= t[w+1] << (64 - b)
+	}
+	if n < 64 {
+		v &= 1<<uint(n) - 1
+	}
+	return v
+}
+
+// xorBits xors v into t starting at bit pos.
+//
+func xorBits(t Poly, pos int, v uint64) {
+	w, b := pos/64, uint(pos%64)
+	t[w] ^= v << b
+	if b != 0 && v>>(64-b) != 0 {
+		t[w+1] ^= v >> (64 - b)
+	}
+}
+
+// spread interleaves the bits of v with zeros.
+//
+func spread(v uint32) uint64 {
+	x := uint64(v)
+	x = (x | x<<16) & 0x0000FFFF0000FFFF
+	x = (x | x<<8) & 0x00FF00FF00FF00FF
+	x = (x | x<<4) & 0x0F0F0F0F0F0F0F0F
+	x = (x | x<<2) & 0x3333333333333333
+	x = (x | x<<1) & 0x5555555555555555
+	return x
+}
+
+func words(n int) int {
+	return (n + 63) / 64
+}
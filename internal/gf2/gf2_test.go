@@ -0,0 +1,50 @@
+package gf2_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/db47h/rand64/v3/internal/gf2"
+)
+
+func TestMod_XPow(t *testing.T) {
+	// characteristic polynomials and jump polynomials for xoshiro256 and
+	// xoroshiro128 as found in the reference implementations.
+	tests := []struct {
+		name string
+		n    int
+		low  gf2.Poly
+		e    uint
+		want gf2.Poly
+	}{
+		{"xoshiro256 jump", 256, gf2.Poly{0x9d116f2bb0f0f001, 0x0280002bcefd1a5e, 0x04b4edcf26259f85, 0x0003c03c3f3ecb19},
+			128, gf2.Poly{0x180ec6d33cfd0aba, 0xd5a61266f0c9392c, 0xa9582618e03fc9aa, 0x39abdc4529b1661c}},
+		{"xoshiro256 long jump", 256, gf2.Poly{0x9d116f2bb0f0f001, 0x0280002bcefd1a5e, 0x04b4edcf26259f85, 0x0003c03c3f3ecb19},
+			192, gf2.Poly{0x76e15d3efefdcbbf, 0xc5004e441c522fb3, 0x77710069854ee241, 0x39109bb02acbe635}},
+		{"xoroshiro128 jump", 128, gf2.Poly{0x095b8f76579aa001, 0x0008828e513b43d5},
+			64, gf2.Poly{0xdf900294d8f554a5, 0x170865df4b3201fc}},
+		{"xoroshiro128 long jump", 128, gf2.Poly{0x095b8f76579aa001, 0x0008828e513b43d5},
+			96, gf2.Poly{0xd2a98b26625eee7b, 0xdddf9b1090aa7ac1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := gf2.NewMod(tt.n, tt.low)
+			e := new(big.Int).Lsh(big.NewInt(1), tt.e)
+			got := m.XPow(e)
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("x^(2^%d): got %x, expected %x", tt.e, got, tt.want)
+				}
+			}
+			// x^(2^n-1) = 1
+			e.Lsh(big.NewInt(1), uint(tt.n))
+			e.Sub(e, big.NewInt(1))
+			got = m.XPow(e)
+			for i := range got {
+				if i == 0 && got[i] != 1 || i > 0 && got[i] != 0 {
+					t.Fatalf("x^(2^%d-1): got %x, expected 1", tt.n, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+/*
+Package rustseed implements the seeding conventions of the SeedableRng trait of
+Rust's rand_core crate.
+*/
+package rustseed
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// FromU64 fills s like the default implementation of
+// SeedableRng::seed_from_u64: the seed bytes are filled with the output of a
+// PCG32 generator, then read as little-endian words.
+//
+func FromU64(s []uint64, state uint64) {
+	for i := range s {
+		lo := pcg32(&state)
+		s[i] = uint64(pcg32(&state))<<32 | uint64(lo)
+	}
+}
+
+// FromBytes reads the little-endian words of seed into s, like from_seed does
+// for generators with 64 bits words. It returns false if all words are zero.
+//
+func FromBytes(s []uint64, seed []byte) bool {
+	var nz uint64
+	for i := range s {
+		s[i] = binary.LittleEndian.Uint64(seed[8*i:])
+		nz |= s[i]
+	}
+	return nz != 0
+}
+
+func pcg32(state *uint64) uint32 {
+	const (
+		mul = 6364136223846793005
+		inc = 11634580027462260723
+	)
+	// advance first to get away from the input value
+	*state = *state*mul + inc
+	s := *state
+	return bits.RotateLeft32(uint32(((s>>18)^s)>>27), -int(s>>59))
+}
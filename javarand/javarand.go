@@ -0,0 +1,173 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+/*
+Package javarand provides a source of pseudo-random numbers that is bit-exact
+compatible with Java's java.util.Random.
+
+Given the same seed, java.util.Random and javarand.Rand produce the same
+values:
+
+	Java                           Go
+	Random r = new Random(seed);   r := javarand.New(seed)
+	r.setSeed(seed)                r.Seed(seed)
+	r.next(bits)                   r.Next(bits)
+	r.nextInt()                    r.NextInt()
+	r.nextInt(bound)               r.NextIntn(bound)
+	r.nextLong()                   r.NextLong()
+	r.nextBoolean()                r.NextBoolean()
+	r.nextFloat()                  r.NextFloat()
+	r.nextDouble()                 r.NextDouble()
+	r.nextGaussian()               r.NextGaussian()
+	r.nextBytes(b)                 r.NextBytes(b)
+
+java.util.SplittableRandom is implemented by splitmix64.SplittableRng.
+*/
+package javarand
+
+import (
+	"math"
+)
+
+const (
+	multiplier = 0x5DEECE66D
+	addend     = 0xB
+	mask       = 1<<48 - 1
+)
+
+// Rand is a port of java.util.Random, a 48 bits linear congruential generator.
+//
+// The zero value Rand{} is a valid generator, equivalent to
+// new Random(0x5DEECE66D).
+//
+type Rand struct {
+	seed     uint64 // 48 bits LCG state
+	gauss    float64
+	hasGauss bool
+}
+
+// New returns a new Rand seeded with seed, like new Random(seed).
+//
+func New(seed int64) *Rand {
+	r := &Rand{}
+	r.Seed(seed)
+	return r
+}
+
+// Seed sets the seed of the generator, like setSeed(seed). The seed is
+// scrambled with the LCG multiplier and only its low 48 bits are used.
+//
+func (r *Rand) Seed(seed int64) {
+	r.seed = (uint64(seed) ^ multiplier) & mask
+	r.hasGauss = false
+}
+
+// Next returns the next pseudo-random number with the given number of random
+// bits, like next(bits). bits must be in the range [1, 32].
+//
+func (r *Rand) Next(bits uint) int32 {
+	r.seed = (r.seed*multiplier + addend) & mask
+	return int32(r.seed >> (48 - bits))
+}
+
+// NextInt returns a pseudo-random int32, like nextInt().
+//
+func (r *Rand) NextInt() int32 {
+	return r.Next(32)
+}
+
+// NextIntn returns a pseudo-random int32 in [0, bound), like nextInt(bound).
+//
+// NextIntn panics if bound <= 0.
+//
+func (r *Rand) NextIntn(bound int32) int32 {
+	if bound <= 0 {
+		panic("javarand: invalid argument to NextIntn")
+	}
+	v := r.Next(31)
+	m := bound - 1
+	if bound&m == 0 { // power of two
+		return int32(int64(bound) * int64(v) >> 31)
+	}
+	for u := v; ; u = r.Next(31) {
+		// u - v + m overflows when u is in the last, partial, range.
+		if v = u % bound; u-v+m >= 0 {
+			return v
+		}
+	}
+}
+
+// NextLong returns a pseudo-random int64, like nextLong(). Since it is built
+// from two 32 bits values, not all int64 values can be returned.
+//
+func (r *Rand) NextLong() int64 {
+	return int64(r.Next(32))<<32 + int64(r.Next(32))
+}
+
+// NextBoolean returns a pseudo-random bool, like nextBoolean().
+//
+func (r *Rand) NextBoolean() bool {
+	return r.Next(1) != 0
+}
+
+// NextFloat returns a pseudo-random float32 in [0.0, 1.0), like nextFloat().
+//
+func (r *Rand) NextFloat() float32 {
+	return float32(r.Next(24)) / (1 << 24)
+}
+
+// NextDouble returns a pseudo-random float64 in [0.0, 1.0), like
+// nextDouble().
+//
+func (r *Rand) NextDouble() float64 {
+	return float64(int64(r.Next(26))<<27+int64(r.Next(27))) * (1.0 / (1 << 53))
+}
+
+// NextGaussian returns a normally distributed float64 with mean 0 and
+// standard deviation 1, like nextGaussian(). Values are generated in pairs
+// with the polar method, the second value being cached for the next call.
+//
+func (r *Rand) NextGaussian() float64 {
+	if r.hasGauss {
+		r.hasGauss = false
+		return r.gauss
+	}
+	var v1, v2, s float64
+	for {
+		v1 = 2*r.NextDouble() - 1
+		v2 = 2*r.NextDouble() - 1
+		// explicit conversions prevent fused multiply-adds
+		s = float64(v1*v1) + float64(v2*v2)
+		if s < 1 && s != 0 {
+			break
+		}
+	}
+	m := math.Sqrt(-2 * strictLog(s) / s)
+	r.gauss, r.hasGauss = v2*m, true
+	return v1 * m
+}
+
+// NextBytes fills b with pseudo-random bytes, like nextBytes(b).
+//
+func (r *Rand) NextBytes(b []byte) {
+	for i := 0; i < len(b); {
+		for v, n := r.NextInt(), 4; n > 0 && i < len(b); v, n = v>>8, n-1 {
+			b[i] = byte(v)
+			i++
+		}
+	}
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64. It returns the
+// same values as NextLong.
+//
+func (r *Rand) Uint64() uint64 {
+	return uint64(r.NextLong())
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (r *Rand) Int63() int64 {
+	return int64(r.Uint64() >> 1)
+}
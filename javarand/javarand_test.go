@@ -0,0 +1,71 @@
+package javarand_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/db47h/rand64/v3/javarand"
+)
+
+func Example() {
+	r := javarand.New(42)
+	// r.nextInt(10)
+	for i := 0; i < 5; i++ {
+		fmt.Printf(" %d", r.NextIntn(10))
+	}
+	fmt.Println()
+	// r.nextGaussian()
+	r.Seed(42)
+	fmt.Println(r.NextGaussian())
+
+	// Output:
+	//  0 3 8 4 0
+	// 1.1419053154730547
+}
+
+// Expected values from java.util.Random.
+func TestRand(t *testing.T) {
+	tests := []struct {
+		seed       int64
+		nextInt    int32
+		nextLong   int64
+		nextDouble float64
+		nextGauss  float64
+	}{
+		{0, -1155484576, -4962768465676381896, 0.730967787376657, 0.8025330637390305},
+		{42, -1170105035, -5025562857975149833, 0.7275636800328681, 1.1419053154730547},
+	}
+	r := new(javarand.Rand)
+	for _, tt := range tests {
+		r.Seed(tt.seed)
+		if v := r.NextInt(); v != tt.nextInt {
+			t.Fatalf("seed %d: nextInt: expected %d, got %d", tt.seed, tt.nextInt, v)
+		}
+		r.Seed(tt.seed)
+		if v := r.NextLong(); v != tt.nextLong {
+			t.Fatalf("seed %d: nextLong: expected %d, got %d", tt.seed, tt.nextLong, v)
+		}
+		r.Seed(tt.seed)
+		if v := r.NextDouble(); v != tt.nextDouble {
+			t.Fatalf("seed %d: nextDouble: expected %v, got %v", tt.seed, tt.nextDouble, v)
+		}
+		r.Seed(tt.seed)
+		if v := r.NextGaussian(); v != tt.nextGauss {
+			t.Fatalf("seed %d: nextGaussian: expected %v, got %v", tt.seed, tt.nextGauss, v)
+		}
+	}
+}
+
+func TestRand_NextBytes(t *testing.T) {
+	r := javarand.New(42)
+	b := make([]byte, 7)
+	r.NextBytes(b)
+	r.Seed(42)
+	v0, v1 := r.NextInt(), r.NextInt()
+	want := []byte{byte(v0), byte(v0 >> 8), byte(v0 >> 16), byte(v0 >> 24), byte(v1), byte(v1 >> 8), byte(v1 >> 16)}
+	for i := range b {
+		if b[i] != want[i] {
+			t.Fatalf("NextBytes: expected %v, got %v", want, b)
+		}
+	}
+}
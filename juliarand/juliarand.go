@@ -0,0 +1,116 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+/*
+Package juliarand provides a source of pseudo-random numbers that is bit-exact
+compatible with Julia's default random number generator, TaskLocalRNG.
+
+Since version 1.7, Julia uses a xoshiro256++ generator local to each task. Given
+the same seed, Julia and juliarand produce the same values:
+
+	Julia                          Go
+	Random.seed!(n)                r := juliarand.New(n)
+	rand(UInt64)                   r.Uint64()
+	rand()                         r.Float64()
+	Threads.@spawn ...             child := r.Fork()
+
+Fork implements the task splitting algorithm of Julia 1.10, jl_rng_split in
+src/task.c, and its constants are the ones of that release. Julia 1.7 to 1.9
+derived child tasks differently, and later releases may change the algorithm
+again. Random numbers generated in a child task do not affect the parent task's
+sequence and forking does not alter the values subsequently produced by the
+parent.
+*/
+package juliarand
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+
+	"github.com/db47h/rand64/v3/xoshiro"
+)
+
+// TaskLocalRNG reproduces the state of Julia's TaskLocalRNG for a given task:
+// a xoshiro256++ generator plus an additional register used when spawning
+// child tasks.
+//
+// A zero TaskLocalRNG is not valid and must be seeded before use.
+//
+type TaskLocalRNG struct {
+	xoshiro.Rng256PP
+	s4 uint64 // LCG state used by Fork
+}
+
+// New returns a new TaskLocalRNG seeded like Random.seed!(seed).
+//
+func New(seed int64) *TaskLocalRNG {
+	r := &TaskLocalRNG{}
+	r.Seed(seed)
+	return r
+}
+
+// Seed seeds r like Random.seed!(seed). The state of the generator is the
+// SHA-256 hash of the 32 bits words of seed, least significant first.
+//
+// Negative seeds are hashed like in Julia 1.11 and later; older versions
+// reject them.
+//
+func (r *TaskLocalRNG) Seed(seed int64) {
+	h := sha256.New()
+	var b [4]byte
+	n := uint64(seed)
+	if seed < 0 {
+		n = ^n
+	}
+	for {
+		binary.LittleEndian.PutUint32(b[:], uint32(n))
+		h.Write(b[:])
+		if n >>= 32; n == 0 {
+			break
+		}
+	}
+	if seed < 0 {
+		// make sure the hash of negative numbers is different from the
+		// hash of positive numbers
+		h.Write([]byte{1})
+	}
+	var sum [sha256.Size]byte
+	h.Sum(sum[:0])
+	for i := range r.Rng256PP {
+		r.Rng256PP[i] = binary.LittleEndian.Uint64(sum[8*i:])
+	}
+	r.s4 = 1*r.Rng256PP[0] + 3*r.Rng256PP[1] + 5*r.Rng256PP[2] + 7*r.Rng256PP[3]
+}
+
+// Float64 returns a pseudo-random float64 in [0.0, 1.0), like rand().
+//
+func (r *TaskLocalRNG) Float64() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Fork returns the TaskLocalRNG of a new task spawned from the task using r,
+// like jl_rng_split in Julia 1.10. Only the additional register of r is
+// updated: the values subsequently returned by r are unchanged.
+//
+func (r *TaskLocalRNG) Fork() *TaskLocalRNG {
+	// random xor constants
+	a := [4]uint64{0x214c146c88e47cb7, 0xa66d8cc21285aafa, 0x68c7ef2d7b1a54d4, 0xb053a7d7aa238c61}
+	// random multipliers
+	m := [4]uint64{0xaef17502108ef2d9, 0xf34026eeb86766af, 0x38fd70ad58dd9fbb, 0x6677f9b93ab0c04d}
+
+	// load and advance the LCG state (high spectrum multiplier)
+	x := r.s4
+	r.s4 = x*0xd1342543de82ef95 + 1
+	child := &TaskLocalRNG{s4: r.s4}
+	// PCG-RXS-M-XS-64 output with four variants
+	for i, c := range r.Rng256PP {
+		w := x ^ a[i]
+		c += w * (2*c + 1)
+		c ^= c >> ((c >> 59) + 5)
+		c *= m[i]
+		c ^= c >> 43
+		child.Rng256PP[i] = c
+	}
+	return child
+}
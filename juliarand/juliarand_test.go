@@ -0,0 +1,65 @@
+package juliarand_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/db47h/rand64/v3/juliarand"
+)
+
+func Example() {
+	// julia> Random.seed!(1234); rand(2)
+	r := juliarand.New(1234)
+	fmt.Println(r.Float64(), r.Float64())
+
+	// Output:
+	// 0.32597672886359486 0.5490511363155669
+}
+
+// The expected values of the following tests are regression values computed
+// with this package; unlike the ones of Example, they have not been checked
+// against a Julia release.
+func TestTaskLocalRNG_Seed(t *testing.T) {
+	for _, tt := range []struct {
+		seed int64
+		want uint64
+	}{
+		{42, 11609368420648930672},
+		{1 << 40, 11354206972884279544},
+		{-1, 4751444371694501402},
+	} {
+		if v := juliarand.New(tt.seed).Uint64(); v != tt.want {
+			t.Fatalf("seed %d: expected %d, got %d", tt.seed, tt.want, v)
+		}
+	}
+}
+
+func TestTaskLocalRNG_Fork(t *testing.T) {
+	r := juliarand.New(42)
+	ref := juliarand.New(42)
+	r.Uint64()
+	r.Float64()
+	ref.Uint64()
+	ref.Float64()
+	c := r.Fork()
+	if v := c.Uint64(); v != 15159690158435978584 {
+		t.Fatalf("child: expected 15159690158435978584, got %d", v)
+	}
+	if v := c.Fork().Uint64(); v != 5532489005410586303 {
+		t.Fatalf("grandchild: expected 5532489005410586303, got %d", v)
+	}
+	if v := r.Uint64(); v != 8806607393865937907 {
+		t.Fatalf("parent: expected 8806607393865937907, got %d", v)
+	}
+	ref.Uint64()
+	// forking does not change the parent's sequence
+	for i := 0; i < 10; i++ {
+		if a, b := r.Uint64(), ref.Uint64(); a != b {
+			t.Fatalf("parent: expected %d, got %d", b, a)
+		}
+	}
+	// but successive children differ
+	if a, b := r.Fork().Uint64(), r.Fork().Uint64(); a == b {
+		t.Fatalf("successive children produce the same value %d", a)
+	}
+}
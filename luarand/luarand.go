@@ -0,0 +1,109 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+/*
+Package luarand provides a source of pseudo-random numbers that is bit-exact
+compatible with Lua 5.4's math.random.
+
+Lua 5.4 uses xoshiro256** with its own seeding and conversion functions. Given
+the same seed, Lua and luarand.Rand produce the same values:
+
+	Lua                         Go
+	math.randomseed(n)          r.Seed(n)
+	math.randomseed(n1, n2)     r.RandomSeed(n1, n2)
+	math.random()               r.Random()
+	math.random(0)              r.RandomN(0) or int64(r.Uint64())
+	math.random(m)              r.RandomN(m)
+	math.random(m, n)           r.RandomRange(m, n)
+
+The project algorithm used for ranges is the one of Lua 5.4.0 to 5.4.6.
+*/
+package luarand
+
+import (
+	"github.com/db47h/rand64/v3/xoshiro"
+)
+
+// Rand is a xoshiro.Rng256SS seeded and used the way Lua 5.4 does. All the
+// methods of Rng256SS except Seed are available and operate on the same state
+// as Lua's generator.
+//
+type Rand struct {
+	xoshiro.Rng256SS
+}
+
+// New returns a new Rand seeded like math.randomseed(n1, n2).
+//
+func New(n1, n2 int64) *Rand {
+	r := &Rand{}
+	r.RandomSeed(n1, n2)
+	return r
+}
+
+// Seed seeds r like math.randomseed(seed).
+//
+func (r *Rand) Seed(seed int64) {
+	r.RandomSeed(seed, 0)
+}
+
+// RandomSeed seeds r like math.randomseed(n1, n2). The first 16 values of the
+// new sequence are discarded in order to spread the seed.
+//
+func (r *Rand) RandomSeed(n1, n2 int64) {
+	r.Rng256SS = xoshiro.Rng256SS{uint64(n1), 0xff, uint64(n2), 0} // 0xff avoids a zero state
+	for i := 0; i < 16; i++ {
+		r.Uint64()
+	}
+}
+
+// Random returns a pseudo-random float64 in [0.0, 1.0), like math.random().
+//
+func (r *Rand) Random() float64 {
+	return float64(r.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// RandomN returns a pseudo-random int64 in [1, m], like math.random(m). As a
+// special case, RandomN(0) returns an integer with all bits random.
+//
+// RandomN panics if m < 0.
+//
+func (r *Rand) RandomN(m int64) int64 {
+	if m == 0 {
+		return int64(r.Uint64())
+	}
+	return r.RandomRange(1, m)
+}
+
+// RandomRange returns a pseudo-random int64 in [m, n], like math.random(m, n).
+//
+// RandomRange panics if n < m.
+//
+func (r *Rand) RandomRange(m, n int64) int64 {
+	v := r.Uint64()
+	if m > n {
+		panic("luarand: interval is empty")
+	}
+	return int64(r.project(v, uint64(n)-uint64(m)) + uint64(m))
+}
+
+// project projects the random value v into [0, n], drawing new values if
+// needed, exactly like Lua's project function.
+//
+func (r *Rand) project(v, n uint64) uint64 {
+	if n&(n+1) == 0 { // n + 1 is a power of 2
+		return v & n
+	}
+	// compute the smallest 2^b - 1 not smaller than n
+	lim := n
+	lim |= lim >> 1
+	lim |= lim >> 2
+	lim |= lim >> 4
+	lim |= lim >> 8
+	lim |= lim >> 16
+	lim |= lim >> 32
+	for v &= lim; v > n; v &= lim {
+		v = r.Uint64()
+	}
+	return v
+}
@@ -0,0 +1,55 @@
+package luarand_test
+
+import (
+	"testing"
+
+	"github.com/db47h/rand64/v3/luarand"
+)
+
+// Reference values from the "particular values of the generator" test in
+// testes/math.lua of Lua 5.4: math.randomseed(1007) followed by math.random(0)
+// or math.random().
+func TestRand_Seed(t *testing.T) {
+	const want = 0x7a7040a5a323c9d6
+	r := luarand.New(1007, 0)
+	if v := uint64(r.RandomN(0)); v != want {
+		t.Fatalf("RandomN(0): expected %#x, got %#x", uint64(want), v)
+	}
+	r.Seed(1007)
+	if v, w := r.Random(), float64(want>>11)/(1<<53); v != w {
+		t.Fatalf("Random: expected %v, got %v", w, v)
+	}
+}
+
+func TestRand_RandomRange(t *testing.T) {
+	tests := []struct{ m, n int64 }{
+		{1, 1}, {1, 6}, {-10, 10}, {0, 255}, {1, 1 << 40}, {-1 << 63, 1<<63 - 1}, {-1 << 63, 0},
+	}
+	r := luarand.New(1, 2)
+	for _, tt := range tests {
+		for i := 0; i < 1000; i++ {
+			if v := r.RandomRange(tt.m, tt.n); v < tt.m || v > tt.n {
+				t.Fatalf("RandomRange(%d, %d): got out of range value %d", tt.m, tt.n, v)
+			}
+		}
+	}
+	// The seeding being checked by TestRand_Seed, the following checks follow
+	// the raw output of the generator through the project function of
+	// lmathlib.c. Values outside of [0, n] are rejected.
+	r.Seed(3)
+	rng := luarand.New(3, 0).Rng256SS
+	for i := 0; i < 100; i++ {
+		var w uint64
+		for w = rng.Uint64() & 7; w > 5; w = rng.Uint64() & 7 {
+		}
+		if v := r.RandomN(6); v != int64(w)+1 {
+			t.Fatalf("RandomN(6): expected %d, got %d", w+1, v)
+		}
+	}
+	// power of two ranges use the low bits
+	r.Seed(3)
+	rng = luarand.New(3, 0).Rng256SS
+	if v, w := r.RandomRange(0, 255), int64(rng.Uint64()&255); v != w {
+		t.Fatalf("RandomRange(0, 255): expected %d, got %d", w, v)
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package mt19937
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/db47h/rand64/v3/internal/gf2"
+)
+
+// Jump advances the generator's state by 2^128 steps. It is equivalent to 2^128
+// calls to Uint64; it can be used to generate 2^128 non-overlapping
+// subsequences for parallel computations.
+//
+// This uses the polynomial jump method from H. Haramoto, M. Matsumoto, T.
+// Nishimura, F. Panneton, P. L'Ecuyer, "Efficient Jump Ahead for F2-Linear
+// Random Number Generators", INFORMS Journal on Computing 20(3), 2008, with a
+// precomputed jump polynomial.
+//
+func (rng *Rng) Jump() {
+	rng.jump(jumpPoly[:])
+}
+
+// Advance advances the generator's state by n steps. It is equivalent to n
+// calls to Uint64, but only takes O(log n) polynomial operations.
+//
+func (rng *Rng) Advance(n uint64) {
+	rng.AdvanceBig(new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig advances the generator's state by n steps. If n is negative, the
+// state is moved backwards by -n steps.
+//
+// The jump polynomial for n must be computed first, which is much slower than
+// applying it. This is especially true for negative values of n, handled as
+// 2^19937-1+n, which take several seconds. Use Jump to advance by 2^128 steps.
+//
+func (rng *Rng) AdvanceBig(n *big.Int) {
+	if n.Sign() == 0 {
+		return
+	}
+	rng.jump(charPoly().XPow(n))
+}
+
+// jump applies the jump polynomial poly to the generator's state.
+//
+// The state is handled as a sliding window of _NN words over the sequence
+// generated by the MT recurrence, where only the 33 upper bits of the oldest
+// word are significant. jump starts from the window whose second word is the
+// next output, so that the whole output word is part of the jumped state. Upon
+// return, the next output is at index 1 of rng.state.
+//
+func (rng *Rng) jump(poly gf2.Poly) {
+	if rng.index == 0 {
+		rng.Seed(5489)
+	}
+	// Uint64 leaves rng.index in the range [1, _NN], so that the position of
+	// the next output is in the range [1, _NN] (_NN meaning that the next
+	// output will be mt[0] after the next twist).
+	w := window{s: rng.state}
+	for i := 1; i < int(_NN+1-rng.index); i++ {
+		w.next()
+	}
+	var r [_NN]uint64
+	for i := 0; i < len(poly)*64; i++ {
+		if poly[i/64]>>uint(i%64)&1 != 0 {
+			w.addTo(&r)
+		}
+		w.next()
+	}
+	rng.state = r
+	rng.index = _NN
+}
+
+// window is a circular buffer holding _NN consecutive words of the MT
+// sequence, the oldest being at index i.
+//
+type window struct {
+	s [_NN]uint64
+	i int
+}
+
+// next slides w by one word.
+//
+func (w *window) next() {
+	i := w.i
+	i1 := i + 1
+	if i1 == _NN {
+		i1 = 0
+	}
+	im := i + _MM
+	if im >= _NN {
+		im -= _NN
+	}
+	x := (w.s[i] & _UM) | (w.s[i1] & _LM)
+	w.s[i] = w.s[im] ^ (x >> 1) ^ mag01[x&1]
+	w.i = i1
+}
+
+// addTo xors the words of w into r, oldest first.
+//
+func (w *window) addTo(r *[_NN]uint64) {
+	n := _NN - w.i
+	for j, v := range w.s[w.i:] {
+		r[j] ^= v
+	}
+	for j, v := range w.s[:w.i] {
+		r[n+j] ^= v
+	}
+}
+
+var (
+	charPolyOnce sync.Once
+	charPolyMod  *gf2.Mod
+)
+
+// charPoly returns the ring of polynomials modulo the characteristic
+// polynomial of MT19937-64.
+//
+func charPoly() *gf2.Mod {
+	charPolyOnce.Do(func() {
+		charPolyMod = gf2.NewMod(19937, charPolyLow[:])
+	})
+	return charPolyMod
+}
+
+// charPolyLow holds the coefficients of the characteristic polynomial of
+// MT19937-64, without its x^19937 term. It has been computed by applying the
+// Berlekamp-Massey algorithm to the sequence of the least significant bits of
+// the generator's output.
+//
+var charPolyLow = [_NN]uint64{
+	0x0000000000000001, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0100000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000100000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000000010, 0x0000000000000000, 0x0000000100000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0010000000000000, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000010000, 0x0000000000000000, 0x0000100000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000001,
+	0x0000000000000000, 0x0000000010000000, 0x0000000000000000, 0x0100000000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0001000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000001000,
+	0x0000000000000000, 0x0000010000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000000010, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	0x1000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000001000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000010000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000000100, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000000001, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0080000000000000, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000100000, 0x0000000000000000, 0x0001a00000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000000000, 0x4000000000000000, 0x0000000000000010,
+	0x0000000000000000, 0x0000000124000000, 0x0000000000000000, 0x1050000000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000001058000, 0x0000000000000000,
+	0x0000400000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000010480,
+	0x0000000000000000, 0x0000004100000000, 0x0000000000000000, 0x1800000000000000,
+	0x0000000000000104, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0008000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000010110000,
+	0x0000000000000000, 0x0001980000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000100004, 0x0000000000000000, 0x0001008860000000, 0x0000000000000000,
+	0x0400000000000000, 0x0000000000001001, 0x0000000000000000, 0x0000000018400000,
+	0x0000000000000000, 0x0000400000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000082600, 0x0000000000000000, 0x0001005000000000, 0x0000000000000000,
+	0x8000000000000000, 0x0000000001001805, 0x0000000000000000, 0x0000000040000000,
+	0x0000000000000000, 0x04a0000000000000, 0x0000000000010008, 0x0000000000000000,
+	0x0000000000400000, 0x0000000000000000, 0x0004000000000000, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000000040, 0x0000000000000000, 0x0000022600000000,
+	0x0000000000000001, 0x4000000000000000, 0x0000000000000010, 0x0000000000000000,
+	0x0080000184000000, 0x0000000000000000, 0x0040000000000000, 0x0000000000000004,
+	0x0000000000000000, 0x0000a00060a40000, 0x0000000000000000, 0x0400400000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000400400, 0x0000000000000000,
+	0x4000404000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000024002624,
+	0x0000000000000000, 0x0050005040000000, 0x0000000000000000, 0x8400000000000000,
+	0x0000000000058005, 0x0000000000000000, 0x0000400040400000, 0x0000000000000000,
+	0x04a4000000000000, 0x0000000000000480, 0x0000000000000000, 0x0000004100404000,
+	0x0000000000000000, 0x1804040000000000, 0x0000000000000004, 0x0000000000000000,
+	0x0000000000000040, 0x0000000000000000, 0x0008022400000000, 0x0000000000000000,
+	0x4000000000000000, 0x0000000000110010, 0x0000000000000000, 0x0001980184000000,
+	0x0000000000000000, 0x0040000000000000, 0x0000000000000004, 0x0000000000000000,
+	0x0000008860a40000, 0x0000000000000000, 0x0400400000000000, 0x0000000000000001,
+	0x0000000000000000, 0x0000000018400400, 0x0000000000000000, 0x0000404000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000082624, 0x0000000000000000,
+	0x0001005040000000, 0x0000000000000000, 0x8400000000000000, 0x0000000000001805,
+	0x0000000000000000, 0x0000000040400000, 0x0000000000000000, 0x04a4000000000000,
+	0x0000000000000008, 0x0000000000000000, 0x0000000000404000, 0x0000000000000000,
+	0x0004040000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000040,
+	0x0000000000000000, 0x0000022400000000, 0x0000000000000000, 0x4000000000000000,
+	0x0000000000000010, 0x0000000000000000, 0x0000000184000000, 0x0000000000000000,
+	0x0040000000000000, 0x0000000000000004, 0x0000000000000000, 0x0000000060a40000,
+	0x0000000000000000, 0x0400400000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000400400, 0x0000000000000000, 0x0000404000000000, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000002624, 0x0000000000000000, 0x0000005040000000,
+	0x0000000000000000, 0x8400000000000000, 0x0000000000000005, 0x0000000000000000,
+	0x0000000040400000, 0x0000000000000000, 0x04a4000000000000, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000404000, 0x0000000000000000, 0x0004040000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000000040, 0x0000000000000000,
+	0x0000022400000000, 0x0000000000000000, 0x4000000000000000, 0x0000000000000010,
+	0x0000000000000000, 0x0000000184000000, 0x0000000000000000, 0x0040000000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000a40000, 0x0000000000000000,
+	0x0000400000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000400,
+	0x0000000000000000, 0x0000004000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000000024, 0x0000000000000000, 0x0000000040000000, 0x0000000000000000,
+	0x0400000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000400000,
+	0x0000000000000000, 0x0004000000000000, 0x0000000000000000, 0x0000000000000000,
+	0x0000000000004000, 0x0000000000000000, 0x0000040000000000, 0x0000000000000000,
+	0x0000000000000000, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000,
+}
+
+// jumpPoly is x^(2^128) modulo the characteristic polynomial.
+//
+var jumpPoly = [_NN]uint64{
+	0x153fbc23409b1e30, 0xb8d58a2efc1cc7be, 0x04cc8df6bd5573e1, 0x8e1b99d6ea322754,
+	0x7fa5c8ab11a78ecf, 0xa3f01992f879dc26, 0x77500e62929d74d1, 0x4c65ef439f2dcb2a,
+	0x731b3bd3538eec46, 0x14cd564c40c9e3ae, 0x6ff65677752268b7, 0xbbea104c48ec8b8d,
+	0x08d3565972568ea4, 0x5cb79db1f77395f2, 0x94f5c348a32cecac, 0x4b58cc38b6123ed7,
+	0x64d191a00b3e362c, 0x7b051615bc105659, 0x2ad11e2d812e15d2, 0xd2551d15c944f218,
+	0x68374254d1f46885, 0x72a5fd7700e8c34f, 0xe40b4ac61e14376c, 0xbb107cd0a9158cc0,
+	0x5028a2a3d4ce28e6, 0xd0815eeb2e91aa05, 0x29ba386f6309e7dd, 0xa19bf128091df643,
+	0xa4dda3ea5af247f8, 0x950ff2c8bc8d9f30, 0xc415a0871ef1af4e, 0xe8859d7a5ac3264c,
+	0x4d58e6bed0739fe2, 0xb072d474e3f9602c, 0x93b112035cf0e33d, 0x90d4af56420a0a3d,
+	0xcb930cdffd09ba87, 0x82305413c76ba04a, 0x88ed61ba7dfc9075, 0xdefc75a7869c145c,
+	0x0c16916696775659, 0x94a47bf0b5d3869b, 0x026c4476e2551799, 0x2b22d90027fdd747,
+	0xe447af7718644777, 0xbb83f1c03190e0fa, 0x932fabc717b3114c, 0xe0384041dbd5eafd,
+	0x698ca9a2304fa895, 0xbbb26eff4e2f6627, 0x453cab967a470645, 0x2a6aefabcd19d4e9,
+	0x808f8d33240f6b90, 0x91bf46c93a4b852b, 0x74b6a8597100e697, 0xbd2a4ef239564089,
+	0x9917718e08ec24fa, 0xac9ce650dccc5d61, 0x52db4d76a2c5546c, 0x0123e0fc3cb90aea,
+	0xfe78f1e83bb93635, 0x4f5b739d5ba04851, 0xa4bf7f96e9684a89, 0x5464bb377a97f62e,
+	0x328933f006ce14be, 0x43e558b7d62ae5d7, 0xddb0f33f21e7d8dc, 0x52d2779de93320d2,
+	0x57191c72acfc5093, 0x1779384819ca00e9, 0x7afcfbbe2acaa684, 0x90231d57884a7544,
+	0xdd3ffead4feec6e3, 0x273584a42f1a795d, 0x691601338d2c7449, 0x8c8e419ca0529fc3,
+	0x373e37dd051f8b86, 0x27a2d7161f6d06bd, 0x954240070472311a, 0x471565b60a93d2e4,
+	0x4fb4ad962c328135, 0x7b1a3a92c401e93b, 0xf261c3fcc82af141, 0x57241af08978f3ec,
+	0x2c79aaa370d1bd4f, 0xf35790a0978137d6, 0x38c7263c96234239, 0xe0a13a1dd5f852b5,
+	0x0734f6c962f86802, 0xca52564f72f13f11, 0xa4bd2a9dc69a1248, 0x6f418a04edb45e98,
+	0x764b57a0059aa71a, 0x926f6f5f354266df, 0x60c4150013cc9412, 0x3a14980c9d4ccd96,
+	0x4e5da33944239d8b, 0x23f3ef6e843c729c, 0x389b1022de0ac7c9, 0x369b29d7d285823e,
+	0xf556214ad63e2cd9, 0x90e43b9536bc15ab, 0xa43604007e23fd84, 0x70ee2bd8d9e6c2af,
+	0x0e8b6c7a77fd426a, 0xed09417ce0d73cdf, 0xa3e935e2c81a4021, 0x7cf2e08b288398fa,
+	0x1e933cde96a31115, 0xdb6014c3a780c561, 0x2bf15950b4660f9d, 0x50cf62efc80a3c55,
+	0x448ede02ea0783c5, 0x97df0d14f64c01c7, 0x1353357d543368d0, 0x9bd1449652cdca9c,
+	0x66d15aefa7a24321, 0x25dd75fc7492ba9d, 0x468ce9a1a3874e13, 0x40ab9e8ed67a4ad1,
+	0x0bafb4d323d02677, 0xf9f3d01c1f435b69, 0x0c4a0fa46fac656a, 0xbdac3abdd37e4dfc,
+	0xdf9b06ef05db31df, 0xed005f00f37daa7b, 0x924be2e465b09410, 0x99099376ea87be57,
+	0x302d8a7c49c4be6a, 0xe8effc70541c07a5, 0x6e4611ad196a6ee3, 0xbd42cb15a52cb228,
+	0xce343ee493cdec20, 0x7f4231e3d20e8e72, 0xa2127d2ed81e4f89, 0x27bb32afa1c6ef4c,
+	0x9d37d9f4cb87c492, 0xa6b7e94b15e2287c, 0x098b4d302e16d6e9, 0x12d1da8ffbf3adb2,
+	0xd5be155bc2fc01de, 0x90f630b9e309715b, 0xbdb108b0f8da213c, 0x98ed520d71f49d1a,
+	0x82495aacd19eb9dc, 0x124d7478a15025b2, 0xa0eb607ec4087775, 0xcb47955eeabe0890,
+	0x7360a3d0e0b68b89, 0x25f5bee656159d92, 0xeae8434e13f985ed, 0x04ff38722ad10a86,
+	0xac7097215b434280, 0x3640ae9dd0687b1a, 0xb24209a4ce9f603b, 0xf03e6fd6f7a416dd,
+	0xd31e5bcde48672af, 0x2704ce60eb8429a7, 0xf7aeb81f8fcd00c3, 0x5424dbaa0b636a3c,
+	0xf352fe250d625a64, 0x9cc12556c2228f86, 0xedac0dbb94e94f51, 0xdd8f2b1f26762fd1,
+	0x5ef488076c7e957f, 0x2b734dc8a46c3c61, 0x52111589eb2a22e3, 0xfa11c9bb843df4bc,
+	0x5896ac2ecf36f9d2, 0x66c197a7e49dba0a, 0xe1eda2cd47aefd0f, 0x4cae0acf5d5fa62d,
+	0xcb3e21e3f8d7c943, 0x351580d27b75fe44, 0x6cbd4b5618cbab9b, 0x8e47ef0542e8a51d,
+	0x125adf6b4b59b2ef, 0x2729dc334cacfd5b, 0x883432a737937820, 0x60f002c1dceda4ab,
+	0xafed1be46e7fd2bc, 0xf2a3d1ccbf871115, 0xf85e5c5050ae7160, 0x777cdc44554e6d74,
+	0x0bcf75213e259946, 0x9d0714b4db9ca29a, 0x370fdc4067326a6d, 0xffeb713807a1cea8,
+	0x7fb0a9674a53e792, 0x62b040005f9ce7bb, 0x8903f6b282b67cab, 0x3544ff158026eb52,
+	0xd66590248adf92f1, 0x55de1c87a2ebdf48, 0x40b0382287267aba, 0x7dfa56a6fb26180e,
+	0x45c32d7dc66b19ce, 0xf5ed0edf665034c7, 0xf4c7adbe75e15da0, 0x95db8535e0bd9122,
+	0xc571b09620d82713, 0x9c21ed0e78f021f9, 0xd0cb50a9f9aa8def, 0xbcb3368c4e9ff5b6,
+	0x06d8f649704939a3, 0x5eaa9ee186d14a54, 0x86d1f972fd4883d0, 0x63b1522f4d50d887,
+	0x982b2fba1a9875a7, 0x7258bfd6235930ea, 0xe4ccc8e3c2f0f70e, 0x9bf390d119769362,
+	0x1bcea29dbd2c02be, 0xd9c189db413398c0, 0x988aa44564f85434, 0x007ed1eaeef5e20a,
+	0xa0685fede0eec596, 0xfef177e0b35a7f0e, 0x5006596f191ebc61, 0xcba87c3e61bdbc8a,
+	0xff2174049069bfcb, 0xd7a536ddb2c4f33f, 0xf7aecde21fc2d977, 0xc121dca3feef7800,
+	0xa90ad927d025c16b, 0x3ea6fee532058e96, 0x9f5210df30acdeb9, 0x520e94889837bcff,
+	0x8c6c6a100dabdb5b, 0x6d2101f3fc530774, 0x51d535e6dc645e49, 0xe5e7620ed6a4941b,
+	0xaf8023c107046243, 0x62e6e40f4ea19600, 0x466396ce1ab8e939, 0x470fc344d01a2a69,
+	0x223011f816549f0e, 0x9b0a401733299c57, 0x6e214523ae60b334, 0x84c4cbe45a9b66a6,
+	0x630d39f922b4c0b4, 0xfbfa79ec2c0e1012, 0xe9940485ec80d5c0, 0x1dc1c6fb5a01f32a,
+	0x9cd0b7f3a578e57f, 0x40b6ce9d50e92c04, 0x588b8af39ab91d81, 0x8058dc2783b02de3,
+	0xbb2103c504392c9d, 0x7264692220716211, 0xdb804fcdeb987bba, 0xababd32a49398687,
+	0xe3dee3755b4da875, 0x16de733adb8bb721, 0x99476d13103ffe32, 0x86d2d629666cb05b,
+	0x9c4e62ab740ce645, 0xb59682265b7519ff, 0x54df6930e9ed43fb, 0x33f8218861f98b68,
+	0x21bc749542f06516, 0xd5e9662b4586df7f, 0x465569ea0eb5cce4, 0x36a484c938f0ae75,
+	0xc088cc5189f80399, 0x4becd1a8a2280cde, 0x192f20a74dac06f0, 0xae766a8b287a1565,
+	0x036c05ba6abff5f3, 0x5fe448493d8faf69, 0xa880a8ff94b90ea8, 0xd0ec7c6342d2b77b,
+	0xd187d7068a2cf90f, 0x32523f9ad82e6693, 0x0f87420e87b90726, 0x3a745f953d8e0c35,
+	0x0199993c5a3d1db4, 0x33e45b5766ccb1a0, 0xd2abaac1626e0b0c, 0xad5c3023b061fdfb,
+	0xf67cf6541cb66e52, 0xe9d9083c635a2190, 0x29a103e0c3b4dac8, 0x75f72adb5e7a7e46,
+	0xdcc943ab2ec296da, 0x396a079f137ff14b, 0x67853f3d29182ec1, 0x35dd3e7a7a71c780,
+	0xfbf82a6fa275a546, 0x39cc58a7583f7227, 0x8b1b1aedefea9fed, 0x909f457dada71450,
+	0xc02abfcbfe3e387a, 0xd6871e18b79ae3c1, 0x9f6bac46344f1a0f, 0x3366cd78201abced,
+	0xa9da4a5207175299, 0x030642baf1ad5022, 0x5ae120669a844ab0, 0xd8fc12c876b5dbb7,
+	0x2f92b413a6fc6e34, 0x2f2b5a6b0f30aff4, 0x89633b161fac757a, 0x5e4bf21ca2b399c2,
+	0x5ed834f955dcf6ab, 0xd5fdc80d6fa8e6cd, 0xcdf09ed99544069f, 0xfa9adc855e53297c,
+	0x38fa314d5c46ab53, 0x94508c05dda26a06, 0x7de2dae2aa415d2c, 0x0000000143ed6f2e,
+}
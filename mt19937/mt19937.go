@@ -3,13 +3,14 @@
 // can be found in the LICENSE file.
 
 /*
-Package mt19937 implements a 64-bit version of the Mersenne Twister
-pseudo-random number generator (MT19937 PRNG).
+Package mt19937 implements the 64-bit and 32-bit versions of the Mersenne
+Twister pseudo-random number generator (MT19937 PRNG).
 
-The state size is 312 uint64.
+The state size is 312 uint64 for the 64-bit version (Rng) and 624 uint32 for
+the 32-bit version (Rng32).
 
-This is a pure Go implementation based on the mt19937-64.c C implementation by
-Makoto Matsumoto and Takuji Nishimura.
+This is a pure Go implementation based on the mt19937-64.c and mt19937ar.c C
+implementations by Makoto Matsumoto and Takuji Nishimura.
 
 More information on the Mersenne Twister algorithm and other implementations are
 available from http://www.math.sci.hiroshima-u.ac.jp/~m-mat/MT/emt.html
@@ -2,6 +2,7 @@ package mt19937_test
 
 import (
 	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
@@ -86,3 +87,131 @@ func TestRng_Int63(t *testing.T) {
 		t.Fatalf("%x != %x", i, int64(u>>1))
 	}
 }
+
+func TestRng_Advance(t *testing.T) {
+	var want [2500]uint64
+	var r mt19937.Rng
+	r.Seed(42)
+	for i := range want {
+		want[i] = r.Uint64()
+	}
+	// start from various positions in the state array
+	for _, start := range []int{0, 1, 311, 312, 313, 500} {
+		for _, n := range []uint64{0, 1, 2, 311, 312, 313, 624, 1000} {
+			r.Seed(42)
+			for i := 0; i < start; i++ {
+				r.Uint64()
+			}
+			r.Advance(n)
+			for i := start + int(n); i < start+int(n)+700; i++ {
+				if v := r.Uint64(); v != want[i] {
+					t.Fatalf("start %d, Advance(%d): value %d: expected %d, got %d", start, n, i, want[i], v)
+				}
+			}
+		}
+	}
+
+	// n > 19937 to check polynomial reduction
+	r.Seed(42)
+	r.Uint64()
+	r.Advance(100000)
+	r0 := mt19937.Rng{}
+	r0.Seed(42)
+	for i := 0; i < 100001; i++ {
+		r0.Uint64()
+	}
+	if v, w := r.Uint64(), r0.Uint64(); v != w {
+		t.Fatalf("Advance(100000): expected %d, got %d", w, v)
+	}
+
+	// unseeded Rng
+	r = mt19937.Rng{}
+	r.Advance(1000)
+	r0 = mt19937.Rng{}
+	for i := 0; i < 1000; i++ {
+		r0.Uint64()
+	}
+	if v, w := r.Uint64(), r0.Uint64(); v != w {
+		t.Fatalf("Advance on unseeded Rng: expected %d, got %d", w, v)
+	}
+}
+
+func TestRng_Jump(t *testing.T) {
+	var r, rj mt19937.Rng
+	r.Seed(42)
+	rj.Seed(42)
+	r.Uint64()
+	rj.Uint64()
+	r.AdvanceBig(new(big.Int).Lsh(big.NewInt(1), 128))
+	rj.Jump()
+	for i := 0; i < 1000; i++ {
+		if v, w := rj.Uint64(), r.Uint64(); v != w {
+			t.Fatalf("value %d: expected %d, got %d", i, w, v)
+		}
+	}
+}
+
+func ExampleRng32() {
+	init := []uint32{
+		0x123, 0x234, 0x345, 0x456,
+	}
+	mt := new(mt19937.Rng32)
+	mt.SeedFromSlice(init)
+
+	fmt.Println("10 outputs of Rng32.Uint32()")
+	for i := 0; i < 10; i++ {
+		fmt.Printf(" %10d", mt.Uint32())
+		if i%5 == 4 {
+			fmt.Println()
+		}
+	}
+	for i := 0; i < 990; i++ {
+		mt.Uint32()
+	}
+	fmt.Println("5 more")
+	for i := 0; i < 5; i++ {
+		fmt.Printf(" %10d", mt.Uint32())
+	}
+	fmt.Println()
+
+	// Output:
+	// 10 outputs of Rng32.Uint32()
+	//  1067595299  955945823  477289528 4107218783 4228976476
+	//  3344332714 3355579695  227628506  810200273 2591290167
+	// 5 more
+	//  3276005344 4252045284 4237864172  435643333 1199718579
+}
+
+// Expected values from C++ std::mt19937.
+func TestRng32_Seed(t *testing.T) {
+	tests := []struct {
+		name   string
+		seed   func(r *mt19937.Rng32)
+		values []uint32
+	}{
+		{"unseeded", func(r *mt19937.Rng32) {}, []uint32{3499211612, 581869302, 3890346734}},
+		{"5489", func(r *mt19937.Rng32) { r.Seed(5489) }, []uint32{3499211612, 581869302, 3890346734}},
+		{"0", func(r *mt19937.Rng32) { r.Seed(0) }, []uint32{2357136044, 2546248239, 3071714933}},
+	}
+	for _, tt := range tests {
+		var r mt19937.Rng32
+		tt.seed(&r)
+		for _, v := range tt.values {
+			if n := r.Uint32(); n != v {
+				t.Fatalf("%s: expected %d, got %d", tt.name, v, n)
+			}
+		}
+	}
+	var r mt19937.Rng32
+	r.Seed(42)
+	for i := 0; i < 9999; i++ {
+		r.Uint32()
+	}
+	if n := r.Uint32(); n != 1399405940 {
+		t.Fatalf("expected 1399405940, got %d", n)
+	}
+	r.Seed(0)
+	if n := r.Uint64(); n != 2357136044<<32|2546248239 {
+		t.Fatalf("expected %d, got %d", uint64(2357136044<<32|2546248239), n)
+	}
+}
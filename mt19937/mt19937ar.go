@@ -0,0 +1,138 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package mt19937
+
+const (
+	_NN32      = 624
+	_MM32      = 397
+	_MatrixA32 = 0x9908B0DF
+	_UM32      = 0x80000000 // Most significant bit
+	_LM32      = 0x7FFFFFFF // Least significant 31 bits
+)
+
+var mag01x32 = [...]uint32{
+	0,
+	_MatrixA32,
+}
+
+// Rng32 wraps the state data for the 32-bit MT19937 pseudo-random number
+// generator. This is the generator implemented by mt19937ar.c, C++
+// std::mt19937, Python's random module and many others.
+//
+type Rng32 struct {
+	state [_NN32]uint32 // State vector
+	// index is used in descending order, like in Rng, so that an
+	// uninitialized Rng32 auto-seeds to 5489 like the C version.
+	index uint32
+}
+
+// Seed uses the low 32 bits of the provided seed value to initialize the
+// generator to a deterministic state. This function behaves exactly like
+// init_genrand() in the original C code.
+//
+// Unlike Rng.Seed, a zero seed is not replaced by the default seed 5489.
+//
+func (rng *Rng32) Seed(seed int64) {
+	mt := rng.state[:]
+
+	mt[0] = uint32(seed)
+	for i := uint32(1); i < _NN32; i++ {
+		mt[i] = 1812433253*(mt[i-1]^(mt[i-1]>>30)) + i
+	}
+	rng.index = 1
+}
+
+// SeedFromSlice initializes the state array with data from slice key. This function behaves
+// exactly like init_by_array() in the original C code.
+//
+func (rng *Rng32) SeedFromSlice(key []uint32) {
+	var (
+		i uint32 = 1
+		j uint32
+		k = uint32(len(key))
+	)
+	mt := rng.state[:]
+
+	rng.Seed(19650218)
+
+	if _NN32 > k {
+		k = _NN32
+	}
+	for ; k != 0; k-- {
+		mt[i] = (mt[i] ^ ((mt[i-1] ^ (mt[i-1] >> 30)) * 1664525)) + key[j] + j // non linear
+		i++
+		j++
+		if i >= _NN32 {
+			mt[0] = mt[_NN32-1]
+			i = 1
+		}
+		if j >= uint32(len(key)) {
+			j = 0
+		}
+	}
+	for k = _NN32 - 1; k != 0; k-- {
+		mt[i] = (mt[i] ^ ((mt[i-1] ^ (mt[i-1] >> 30)) * 1566083941)) - i // non linear
+		i++
+		if i >= _NN32 {
+			mt[0] = mt[_NN32-1]
+			i = 1
+		}
+	}
+	mt[0] = 0x80000000
+}
+
+// Uint32 returns a pseudo-random 32-bit value as a uint32. This is the
+// equivalent of genrand_int32() in the original C code.
+//
+func (rng *Rng32) Uint32() uint32 {
+	var i int
+	var y uint32
+	mt := rng.state[:]
+	mti := rng.index
+
+	if mti <= 1 { // generate _NN32 words at once
+		// seed if needed
+		if mti == 0 {
+			rng.Seed(5489)
+		}
+
+		for i = 0; i < _NN32-_MM32; i++ {
+			y = (mt[i] & _UM32) | (mt[i+1] & _LM32)
+			mt[i] = mt[i+_MM32] ^ (y >> 1) ^ mag01x32[y&1]
+		}
+		for ; i < _NN32-1; i++ {
+			y = (mt[i] & _UM32) | (mt[i+1] & _LM32)
+			mt[i] = mt[i+(_MM32-_NN32)] ^ (y >> 1) ^ mag01x32[y&1]
+		}
+		y = (mt[_NN32-1] & _UM32) | (mt[0] & _LM32)
+		mt[_NN32-1] = mt[_MM32-1] ^ (y >> 1) ^ mag01x32[y&1]
+
+		mti = _NN32 + 1
+	}
+
+	y = mt[_NN32+1-mti]
+	rng.index = mti - 1
+
+	y ^= (y >> 11)
+	y ^= (y << 7) & 0x9D2C5680
+	y ^= (y << 15) & 0xEFC60000
+	y ^= (y >> 18)
+
+	return y
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64. The high 32 bits
+// are taken from the first of two successive calls to Uint32.
+//
+func (rng *Rng32) Uint64() uint64 {
+	hi := rng.Uint32()
+	return uint64(hi)<<32 | uint64(rng.Uint32())
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (rng *Rng32) Int63() int64 {
+	return int64(rng.Uint64() >> 1)
+}
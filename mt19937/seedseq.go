@@ -0,0 +1,53 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package mt19937
+
+// A SeedSequence produces seed material for a generator. It is the Go
+// equivalent of the SeedSequence requirement of C++ <random>, like
+// std::seed_seq.
+//
+type SeedSequence interface {
+	// Generate fills dst with 32 bits seed values.
+	Generate(dst []uint32)
+}
+
+// SeedSeq initializes the generator with 624 words generated by seq. It behaves
+// exactly like std::mt19937_64::seed(seq) in C++.
+//
+func (rng *Rng) SeedSeq(seq SeedSequence) {
+	var a [2 * _NN]uint32
+	seq.Generate(a[:])
+	zero := true
+	for i := range rng.state {
+		rng.state[i] = uint64(a[2*i+1])<<32 | uint64(a[2*i])
+		if i == 0 {
+			zero = rng.state[0]&_UM == 0
+		} else if rng.state[i] != 0 {
+			zero = false
+		}
+	}
+	if zero {
+		rng.state[0] = 1 << 63
+	}
+	rng.index = 1
+}
+
+// SeedSeq initializes the generator with 624 words generated by seq. It behaves
+// exactly like std::mt19937::seed(seq) in C++.
+//
+func (rng *Rng32) SeedSeq(seq SeedSequence) {
+	seq.Generate(rng.state[:])
+	zero := rng.state[0]&_UM32 == 0
+	for _, v := range rng.state[1:] {
+		if v != 0 {
+			zero = false
+			break
+		}
+	}
+	if zero {
+		rng.state[0] = 1 << 31
+	}
+	rng.index = 1
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package nprand
+
+import (
+	"math"
+
+	"github.com/db47h/rand64/v3/mt19937"
+)
+
+// RandomState is a port of NumPy's legacy numpy.random.RandomState, built on
+// the 32-bit Mersenne Twister. Calls to np.random.seed(n),
+// np.random.random_sample(), etc. can be replaced by the methods of the same
+// name on a RandomState.
+//
+type RandomState struct {
+	mt       mt19937.Rng32
+	gauss    float64
+	hasGauss bool
+}
+
+// NewRandomState returns a new RandomState seeded with seed, like
+// numpy.random.RandomState(seed). See Seed for the valid range of seed.
+//
+func NewRandomState(seed int64) *RandomState {
+	r := &RandomState{}
+	r.Seed(seed)
+	return r
+}
+
+// Seed seeds r like np.random.seed(seed). NumPy only accepts seeds in the range
+// [0, 2^32); only the low 32 bits of seed are used.
+//
+func (r *RandomState) Seed(seed int64) {
+	r.mt.Seed(seed)
+	r.hasGauss = false
+}
+
+// SeedFromSlice seeds r like np.random.seed(key) where key is an array of
+// integers.
+//
+func (r *RandomState) SeedFromSlice(key []uint32) {
+	r.mt.SeedFromSlice(key)
+	r.hasGauss = false
+}
+
+// Uint32 returns a pseudo-random 32-bit value as a uint32.
+//
+func (r *RandomState) Uint32() uint32 {
+	return r.mt.Uint32()
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (r *RandomState) Uint64() uint64 {
+	return r.mt.Uint64()
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (r *RandomState) Int63() int64 {
+	return r.mt.Int63()
+}
+
+// RandomSample returns a pseudo-random float64 in [0.0, 1.0), like
+// random_sample().
+//
+func (r *RandomState) RandomSample() float64 {
+	a, b := r.mt.Uint32()>>5, r.mt.Uint32()>>6
+	return (float64(a)*67108864.0 + float64(b)) / 9007199254740992.0
+}
+
+// Randint returns a pseudo-random int64 in [low, high), like
+// randint(low, high).
+//
+// Randint panics if high <= low.
+//
+func (r *RandomState) Randint(low, high int64) int64 {
+	if high <= low {
+		panic("nprand: low >= high")
+	}
+	rng := uint64(high - low - 1)
+	mask := genMask(rng)
+	switch {
+	case rng == 0:
+		return low
+	case rng == 0xFFFFFFFF:
+		return low + int64(r.mt.Uint32())
+	case rng < 0xFFFFFFFF:
+		return low + int64(r.masked32(uint32(rng), uint32(mask)))
+	case rng == ^uint64(0):
+		return low + int64(r.mt.Uint64())
+	}
+	for {
+		if v := r.mt.Uint64() & mask; v <= rng {
+			return low + int64(v)
+		}
+	}
+}
+
+// StandardNormal returns a normally distributed float64 with mean 0 and
+// standard deviation 1, like standard_normal(). Values are generated in pairs
+// with the polar method, the second value being cached for the next call.
+//
+func (r *RandomState) StandardNormal() float64 {
+	if r.hasGauss {
+		r.hasGauss = false
+		return r.gauss
+	}
+	var x1, x2, r2 float64
+	for {
+		x1 = 2.0*r.RandomSample() - 1.0
+		x2 = 2.0*r.RandomSample() - 1.0
+		// explicit conversions prevent fused multiply-adds
+		r2 = float64(x1*x1) + float64(x2*x2)
+		if r2 < 1.0 && r2 != 0.0 {
+			break
+		}
+	}
+	f := math.Sqrt(-2.0 * math.Log(r2) / r2)
+	r.gauss, r.hasGauss = f*x1, true
+	return f * x2
+}
+
+// Shuffle pseudo-randomizes the order of n elements, like shuffle(x) where
+// len(x) == n. swap swaps the elements with indexes i and j.
+//
+func (r *RandomState) Shuffle(n int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		swap(i, int(r.interval(uint64(i))))
+	}
+}
+
+// interval returns a random integer in [0, max] like random_interval in NumPy.
+//
+func (r *RandomState) interval(max uint64) uint64 {
+	mask := genMask(max)
+	if max <= 0xFFFFFFFF {
+		return uint64(r.masked32(uint32(max), uint32(mask)))
+	}
+	for {
+		if v := r.mt.Uint64() & mask; v <= max {
+			return v
+		}
+	}
+}
+
+func (r *RandomState) masked32(rng, mask uint32) uint32 {
+	for {
+		if v := r.mt.Uint32() & mask; v <= rng {
+			return v
+		}
+	}
+}
+
+// genMask returns the smallest bit mask >= v.
+//
+func genMask(v uint64) uint64 {
+	v |= v >> 1
+	v |= v >> 2
+	v |= v >> 4
+	v |= v >> 8
+	v |= v >> 16
+	v |= v >> 32
+	return v
+}
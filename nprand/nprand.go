@@ -0,0 +1,233 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+/*
+Package nprand provides sources of pseudo-random numbers that are bit-exact
+compatible with NumPy's random module.
+
+Given the same seed, numpy.random.default_rng(seed) and DefaultRng(seed)
+produce the same values:
+
+	Python                                   Go
+	rng = np.random.default_rng(seed)        rng := nprand.DefaultRng(seed)
+	rng.random()                             rng.Random()
+	rng.integers(low, high)                  rng.Integers(low, high, false)
+	rng.integers(low, high, endpoint=True)   rng.Integers(low, high, true)
+	rng.bit_generator.random_raw()           rng.Uint64()
+
+Seeding goes through a SeedSequence, which can also be used on its own to
+seed PCG64 or PCG64DXSM bit generators and to spawn independent children.
+
+RandomState reproduces NumPy's legacy numpy.random.RandomState, that is the
+functions np.random.seed, np.random.random_sample, etc.
+*/
+package nprand
+
+import (
+	"math/bits"
+
+	"github.com/db47h/rand64/v3/pcg"
+)
+
+// A BitGenerator is the source of raw random bits used by a Generator.
+//
+type BitGenerator interface {
+	Uint64() uint64
+	Uint32() uint32
+}
+
+// PCG64 is a port of numpy.random.PCG64, built on pcg.RngStream.
+//
+type PCG64 struct {
+	rng pcg.RngStream
+	buf uint32 // upper 32 bits of the last Uint64, used by Uint32
+	has bool
+}
+
+// NewPCG64 returns a new PCG64 seeded from seq, like
+// numpy.random.PCG64(seq).
+//
+func NewPCG64(seq *SeedSequence) *PCG64 {
+	g := &PCG64{}
+	g.SeedSequence(seq)
+	return g
+}
+
+// Seed seeds g like numpy.random.PCG64(seed). NumPy rejects negative seeds,
+// which are taken here as their two's complement uint64 value.
+//
+func (g *PCG64) Seed(seed int64) {
+	g.SeedSequence(NewSeedSequence(uint64(seed)))
+}
+
+// SeedSequence seeds g from seq.
+//
+func (g *PCG64) SeedSequence(seq *SeedSequence) {
+	s := seq.GenerateState64(4)
+	g.rng.SeedStream128(s[0], s[1], s[2], s[3])
+	g.has = false
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (g *PCG64) Uint64() uint64 {
+	return g.rng.Uint64()
+}
+
+// Uint32 returns a pseudo-random 32-bit value as a uint32. Like NumPy, each
+// 64-bit draw provides two 32-bit values, low half first.
+//
+func (g *PCG64) Uint32() uint32 {
+	if g.has {
+		g.has = false
+		return g.buf
+	}
+	v := g.rng.Uint64()
+	g.buf, g.has = uint32(v>>32), true
+	return uint32(v)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (g *PCG64) Int63() int64 {
+	return int64(g.Uint64() >> 1)
+}
+
+// Advance advances the state as if n random numbers had been drawn, like
+// PCG64.advance(n). The 32-bit buffer is reset.
+//
+func (g *PCG64) Advance(n uint64) {
+	g.rng.Advance(n)
+	g.has = false
+}
+
+// PCG64DXSM is a port of numpy.random.PCG64DXSM, built on pcg.RngDXSM.
+//
+type PCG64DXSM struct {
+	rng pcg.RngDXSM
+	buf uint32
+	has bool
+}
+
+// NewPCG64DXSM returns a new PCG64DXSM seeded from seq, like
+// numpy.random.PCG64DXSM(seq).
+//
+func NewPCG64DXSM(seq *SeedSequence) *PCG64DXSM {
+	g := &PCG64DXSM{}
+	g.SeedSequence(seq)
+	return g
+}
+
+// Seed seeds g like numpy.random.PCG64DXSM(seed). Negative seeds are taken as
+// their two's complement uint64 value.
+//
+func (g *PCG64DXSM) Seed(seed int64) {
+	g.SeedSequence(NewSeedSequence(uint64(seed)))
+}
+
+// SeedSequence seeds g from seq.
+//
+func (g *PCG64DXSM) SeedSequence(seq *SeedSequence) {
+	s := seq.GenerateState64(4)
+	g.rng.SeedStream128(s[0], s[1], s[2], s[3])
+	g.has = false
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (g *PCG64DXSM) Uint64() uint64 {
+	return g.rng.Uint64()
+}
+
+// Uint32 returns a pseudo-random 32-bit value as a uint32. Like NumPy, each
+// 64-bit draw provides two 32-bit values, low half first.
+//
+func (g *PCG64DXSM) Uint32() uint32 {
+	if g.has {
+		g.has = false
+		return g.buf
+	}
+	v := g.rng.Uint64()
+	g.buf, g.has = uint32(v>>32), true
+	return uint32(v)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (g *PCG64DXSM) Int63() int64 {
+	return int64(g.Uint64() >> 1)
+}
+
+// Generator is a port of numpy.random.Generator.
+//
+type Generator struct {
+	BitGenerator
+}
+
+// DefaultRng returns a Generator backed by a PCG64 bit generator seeded with
+// seed, like numpy.random.default_rng(seed). As with PCG64.Seed, negative seeds
+// are taken as their two's complement uint64 value; use NewSeedSequence for
+// seeds of more than 64 bits.
+//
+func DefaultRng(seed int64) *Generator {
+	return &Generator{NewPCG64(NewSeedSequence(uint64(seed)))}
+}
+
+// Random returns a pseudo-random float64 in [0.0, 1.0), like
+// Generator.random().
+//
+func (g *Generator) Random() float64 {
+	return float64(g.Uint64()>>11) * (1.0 / 9007199254740992.0)
+}
+
+// Integers returns a pseudo-random int64 in [low, high), or [low, high] if
+// endpoint is true, like Generator.integers(low, high, endpoint=endpoint).
+//
+// Integers panics if the range is empty.
+//
+func (g *Generator) Integers(low, high int64, endpoint bool) int64 {
+	if !endpoint {
+		if high <= low {
+			panic("nprand: low >= high")
+		}
+		high--
+	} else if high < low {
+		panic("nprand: low > high")
+	}
+	return low + int64(g.bounded(uint64(high-low)))
+}
+
+// bounded returns a random uint64 in [0, rng] using Lemire's method, exactly
+// like random_bounded_uint64 in NumPy.
+//
+func (g *Generator) bounded(rng uint64) uint64 {
+	switch {
+	case rng == 0:
+		return 0
+	case rng == 0xFFFFFFFF:
+		return uint64(g.Uint32())
+	case rng < 0xFFFFFFFF:
+		rngExcl := uint32(rng) + 1
+		m := uint64(g.Uint32()) * uint64(rngExcl)
+		if leftover := uint32(m); leftover < rngExcl {
+			threshold := (^uint32(0) - uint32(rng)) % rngExcl
+			for leftover < threshold {
+				m = uint64(g.Uint32()) * uint64(rngExcl)
+				leftover = uint32(m)
+			}
+		}
+		return m >> 32
+	case rng == ^uint64(0):
+		return g.Uint64()
+	}
+	rngExcl := rng + 1
+	hi, lo := bits.Mul64(g.Uint64(), rngExcl)
+	if lo < rngExcl {
+		threshold := (^uint64(0) - rng) % rngExcl
+		for lo < threshold {
+			hi, lo = bits.Mul64(g.Uint64(), rngExcl)
+		}
+	}
+	return hi
+}
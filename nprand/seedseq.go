@@ -0,0 +1,155 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package nprand
+
+const (
+	poolSize = 4
+	initA    = 0x43b0d7e5
+	multA    = 0x931e8875
+	initB    = 0x8b51f9dd
+	multB    = 0x58f38ded
+	mixMultL = 0xca01f9dd
+	mixMultR = 0x4973f715
+	xshift   = 16
+)
+
+// SeedSequence mixes sources of entropy in a reproducible way to set the
+// initial state of independent and very probably non-overlapping
+// BitGenerators. It is a port of numpy.random.SeedSequence with the default
+// pool size.
+//
+type SeedSequence struct {
+	entropy  []uint32
+	spawnKey []uint32
+	pool     [poolSize]uint32
+	spawned  uint64
+}
+
+// NewSeedSequence returns a new SeedSequence for the given entropy. A single
+// value is equivalent to SeedSequence(entropy) in NumPy, while several values
+// are equivalent to SeedSequence([entropy...]).
+//
+func NewSeedSequence(entropy ...uint64) *SeedSequence {
+	return newSeedSequence(toUint32(entropy), nil)
+}
+
+func newSeedSequence(entropy, spawnKey []uint32) *SeedSequence {
+	s := &SeedSequence{entropy: entropy, spawnKey: spawnKey}
+	s.mixEntropy(s.assembledEntropy())
+	return s
+}
+
+// SpawnKey returns the spawn key of s, that is the indices of s and its
+// ancestors as returned by Spawn.
+//
+func (s *SeedSequence) SpawnKey() []uint32 {
+	return append([]uint32(nil), s.spawnKey...)
+}
+
+// Spawn returns n child SeedSequences. Calling Spawn several times returns
+// distinct children.
+//
+func (s *SeedSequence) Spawn(n int) []*SeedSequence {
+	seqs := make([]*SeedSequence, n)
+	for i := range seqs {
+		key := make([]uint32, len(s.spawnKey), len(s.spawnKey)+2)
+		copy(key, s.spawnKey)
+		seqs[i] = newSeedSequence(s.entropy, append(key, toUint32([]uint64{s.spawned})...))
+		s.spawned++
+	}
+	return seqs
+}
+
+// GenerateState returns n words of seed material, like
+// generate_state(n, np.uint32).
+//
+func (s *SeedSequence) GenerateState(n int) []uint32 {
+	state := make([]uint32, n)
+	var hashConst uint32 = initB
+	for i := range state {
+		v := s.pool[i%poolSize]
+		v ^= hashConst
+		hashConst *= multB
+		v *= hashConst
+		v ^= v >> xshift
+		state[i] = v
+	}
+	return state
+}
+
+// GenerateState64 returns n 64 bits words of seed material, like
+// generate_state(n, np.uint64).
+//
+func (s *SeedSequence) GenerateState64(n int) []uint64 {
+	st := s.GenerateState(2 * n)
+	state := make([]uint64, n)
+	for i := range state {
+		state[i] = uint64(st[2*i+1])<<32 | uint64(st[2*i])
+	}
+	return state
+}
+
+func (s *SeedSequence) assembledEntropy() []uint32 {
+	e := s.entropy
+	if len(s.spawnKey) > 0 && len(e) < poolSize {
+		// pad with zeros to avoid conflicts with spawn keys (gh-16539)
+		e = append(append([]uint32(nil), e...), make([]uint32, poolSize-len(e))...)
+	}
+	return append(append([]uint32(nil), e...), s.spawnKey...)
+}
+
+func (s *SeedSequence) mixEntropy(entropy []uint32) {
+	var hashConst uint32 = initA
+	hashmix := func(v uint32) uint32 {
+		v ^= hashConst
+		hashConst *= multA
+		v *= hashConst
+		v ^= v >> xshift
+		return v
+	}
+	mixer := &s.pool
+	// Add in the entropy up to the pool size.
+	for i := range mixer {
+		if i < len(entropy) {
+			mixer[i] = hashmix(entropy[i])
+		} else {
+			mixer[i] = hashmix(0)
+		}
+	}
+	// Mix all bits together so late bits can affect earlier bits.
+	for src := range mixer {
+		for dst := range mixer {
+			if src != dst {
+				mixer[dst] = mix(mixer[dst], hashmix(mixer[src]))
+			}
+		}
+	}
+	// Add any remaining entropy, mixing each new entropy word with each pool
+	// word.
+	for src := poolSize; src < len(entropy); src++ {
+		for dst := range mixer {
+			mixer[dst] = mix(mixer[dst], hashmix(entropy[src]))
+		}
+	}
+}
+
+func mix(x, y uint32) uint32 {
+	r := mixMultL*x - mixMultR*y
+	return r ^ r>>xshift
+}
+
+// toUint32 converts integers to 32 bits words the way NumPy does: each value
+// is split into 32 bits words, least significant first, 0 being a single word.
+//
+func toUint32(v []uint64) []uint32 {
+	var r []uint32
+	for _, n := range v {
+		r = append(r, uint32(n))
+		if n>>32 != 0 {
+			r = append(r, uint32(n>>32))
+		}
+	}
+	return r
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package pcg
+
+import (
+	"math/big"
+	"math/bits"
+
+	"github.com/db47h/rand64/v3/splitmix64"
+)
+
+// cheapMul is the 64 bits "cheap multiplier" used by the DXSM variant for both
+// the LCG step and the output function.
+//
+const cheapMul = 0xda942042e4dd58b5
+
+// RngDXSM encapsulates a PCG DXSM 128/64 (cheap multiplier LCG) PRNG.
+//
+// This variant replaces the XSL RR output function of Rng with DXSM (double
+// xorshift multiply), which has no known weakness with correlated streams, and
+// uses a 64 bits multiplier for the 128 bits LCG step. It is the PCG64DXSM
+// generator of NumPy, and its output is computed from the state before it is
+// advanced, like pcg_cm_random_r in the C reference implementation.
+//
+// The zero value RngDXSM{} uses the same default increment as Rng.
+//
+type RngDXSM struct {
+	LO uint64 // low 64 bits of 128 bits state
+	HI uint64 // high 64 bits of 128 bits state
+	// inc is the LCG increment XORed with the default increment.
+	inc u128
+}
+
+// Seed uses the provided seed value to initialize the generator to a
+// deterministic state. The generator's stream is left unchanged.
+//
+func (rng *RngDXSM) Seed(seed int64) {
+	src := splitmix64.Rng{}
+	src.Seed(seed)
+	rng.LO = src.Uint64()
+	rng.HI = src.Uint64()
+}
+
+// SeedStream initializes the generator with the given initial state and
+// selects the stream identified by stream.
+//
+// This function behaves exactly like pcg_cm_srandom_r(rng, seed, stream) in
+// the C reference implementation.
+//
+func (rng *RngDXSM) SeedStream(seed, stream uint64) {
+	rng.SeedStream128(0, seed, 0, stream)
+}
+
+// SeedStream128 is like SeedStream, but takes full 128 bits initial state and
+// stream values, given as pairs of high and low 64 bits words. Only the low
+// 127 bits of the stream are significant.
+//
+func (rng *RngDXSM) SeedStream128(seedHi, seedLo, streamHi, streamLo uint64) {
+	state, inc := lcgSeed(u128{seedHi, seedLo}, u128{streamHi, streamLo}, u128{0, cheapMul})
+	rng.HI, rng.LO = state.hi, state.lo
+	rng.inc = u128{inc.hi ^ incHi, inc.lo ^ incLo}
+}
+
+// Increment returns the LCG increment of the generator's current stream as a
+// pair of high and low 64 bits words. The increment is always odd.
+//
+func (rng *RngDXSM) Increment() (hi, lo uint64) {
+	inc := rng.increment()
+	return inc.hi, inc.lo
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (rng *RngDXSM) Uint64() uint64 {
+	// DXSM output on the current state
+	hi := rng.HI
+	hi ^= hi >> 32
+	hi *= cheapMul
+	hi ^= hi >> 48
+	hi *= rng.LO | 1
+
+	// LCG step: state = state * cheapMul + inc
+	h, lo := bits.Mul64(rng.LO, cheapMul)
+	h += rng.HI * cheapMul
+	var c uint64
+	rng.LO, c = bits.Add64(lo, rng.inc.lo^incLo, 0)
+	rng.HI, _ = bits.Add64(h, rng.inc.hi^incHi, c)
+
+	return hi
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (rng *RngDXSM) Int63() int64 {
+	return int64(rng.Uint64() >> 1)
+}
+
+// Advance advances the generator's state by n steps. It is equivalent to n
+// calls to Uint64, but runs in O(log n) time.
+//
+func (rng *RngDXSM) Advance(n uint64) {
+	rng.advance(u128{0, n})
+}
+
+// Backstep moves the generator's state backwards by n steps, so that the next n
+// calls to Uint64 return the n values previously returned, in reverse order.
+//
+func (rng *RngDXSM) Backstep(n uint64) {
+	rng.advance(u128{0, n}.neg())
+}
+
+// AdvanceBig advances the generator's state by n steps. If n is negative, the
+// state is moved backwards by -n steps. n is taken modulo 2^128.
+//
+func (rng *RngDXSM) AdvanceBig(n *big.Int) {
+	rng.advance(u128FromBig(n))
+}
+
+// Distance returns the number of steps needed to reach the state of other
+// from the state of rng. The result is in the range [0, 2^128). Distance
+// panics if rng and other do not use the same stream.
+//
+func (rng *RngDXSM) Distance(other *RngDXSM) *big.Int {
+	if rng.inc != other.inc {
+		panic("pcg: Distance between generators on different streams")
+	}
+	return lcgDistance(u128{rng.HI, rng.LO}, u128{other.HI, other.LO}, u128{0, cheapMul}, rng.increment()).big()
+}
+
+func (rng *RngDXSM) advance(delta u128) {
+	s := lcgAdvance(u128{rng.HI, rng.LO}, delta, u128{0, cheapMul}, rng.increment())
+	rng.HI, rng.LO = s.hi, s.lo
+}
+
+func (rng *RngDXSM) increment() u128 {
+	return u128{rng.inc.hi ^ incHi, rng.inc.lo ^ incLo}
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package pcg
+
+import "math/bits"
+
+const (
+	// extTickMask selects the low state bits which, when all zero, trigger
+	// an advance of the extension table (advance_pow2 = 16).
+	extTickMask = 1<<16 - 1
+	// rxsmxsUnmul is the multiplicative inverse of the RXS M XS multiplier.
+	rxsmxsUnmul = 15009553638781119849
+)
+
+// RngExt encapsulates an extended PCG XSL RR 128/64 PRNG.
+//
+// An extended generator pairs the base Rng with an extension table of 2^k
+// 64-bit values. Every output of the base generator is XORed with a table entry
+// selected by the low bits of the LCG state, and the whole table is advanced
+// every 2^16 steps, like a multi-digit counter. This gives a period of
+// 2^(128+64*2^k) and 2^k-dimensional equidistribution.
+//
+// RngExt is equivalent to the extended<k, 16, setseq_xsl_rr_128_64,
+// oneseq_rxs_m_xs_64_64, true> engine of the C++ reference implementation. For
+// example, NewExt(10) is a pcg64_k1024 and NewExt(5) a pcg64_k32.
+//
+// RngExt values must be created with NewExt. The zero value RngExt{} has no
+// extension table and is not a valid generator: its Uint64 method panics.
+//
+type RngExt struct {
+	rng  RngStream
+	data []uint64
+}
+
+// NewExt returns a new extended generator with a table of 2^tablePow2 entries,
+// in the same state as a default constructed generator of the C++ reference
+// implementation.
+//
+// NewExt panics if 2^tablePow2 does not fit in an int.
+//
+func NewExt(tablePow2 uint) *RngExt {
+	if tablePow2 >= bits.UintSize-1 {
+		panic("pcg: NewExt table size out of range")
+	}
+	rng := &RngExt{data: make([]uint64, 1<<tablePow2)}
+	state, inc := lcgSeed(u128{0, 0xcafef00dd15ea5e5}, u128{incHi, incLo}.rsh1(), u128{mulHi, mulLo})
+	rng.rng.HI, rng.rng.LO = state.hi, state.lo
+	rng.rng.inc = u128{inc.hi ^ incHi, inc.lo ^ incLo}
+	rng.selfInit()
+	return rng
+}
+
+// Seed uses the provided seed value to initialize the generator to a
+// deterministic state. The generator's stream is left unchanged.
+//
+func (rng *RngExt) Seed(seed int64) {
+	rng.rng.Seed(seed)
+	rng.selfInit()
+}
+
+// SeedStream initializes the generator with the given initial state and
+// selects the stream identified by stream. Like its C++ counterpart, the
+// extension table is then filled with outputs from the base generator.
+//
+func (rng *RngExt) SeedStream(seed, stream uint64) {
+	rng.rng.SeedStream(seed, stream)
+	rng.selfInit()
+}
+
+// SeedStream128 is like SeedStream, but takes full 128 bits initial state and
+// stream values, given as pairs of high and low 64 bits words.
+//
+func (rng *RngExt) SeedStream128(seedHi, seedLo, streamHi, streamLo uint64) {
+	rng.rng.SeedStream128(seedHi, seedLo, streamHi, streamLo)
+	rng.selfInit()
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (rng *RngExt) Uint64() uint64 {
+	lo := rng.rng.LO
+	if lo&extTickMask == 0 {
+		rng.advanceTable()
+	}
+	return rng.rng.Uint64() ^ rng.data[lo&uint64(len(rng.data)-1)]
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (rng *RngExt) Int63() int64 {
+	return int64(rng.Uint64() >> 1)
+}
+
+// selfInit fills the extension table with values from the base generator.
+//
+func (rng *RngExt) selfInit() {
+	lhs := rng.rng.Uint64()
+	rhs := rng.rng.Uint64()
+	xdiff := lhs - rhs
+	for i := range rng.data {
+		rng.data[i] = rng.rng.Uint64() ^ xdiff
+	}
+}
+
+// advanceTable advances the extension table entries. Each entry is the output
+// of an RXS M XS 64/64 generator on its own stream. Entries are advanced like
+// the digits of a counter: when an entry wraps around to 0, the next one is
+// advanced twice.
+//
+func (rng *RngExt) advanceTable() {
+	carry := false
+	for i := range rng.data {
+		if carry {
+			carry = extStep(&rng.data[i], uint64(i+1))
+		}
+		carry2 := extStep(&rng.data[i], uint64(i+1))
+		carry = carry || carry2
+	}
+}
+
+// extStep advances the table entry v on stream i. It returns true if the new
+// value is 0.
+//
+func extStep(v *uint64, i uint64) bool {
+	s := unrxsmxs64(*v)
+	s = s*mul64 + inc64 + i*2
+	*v = rxsmxs64(s)
+	return *v == 0
+}
+
+// unrxsmxs64 is the inverse of rxsmxs64.
+//
+func unrxsmxs64(v uint64) uint64 {
+	v = unxorshift(v, 43)
+	v *= rxsmxsUnmul
+	return unxorshift(v, v>>59+5)
+}
+
+// unxorshift is the inverse of v ^= v >> shift.
+//
+func unxorshift(v, shift uint64) uint64 {
+	x := v
+	for i := shift; i < 64; i += shift {
+		x = v ^ x>>shift
+	}
+	return x
+}
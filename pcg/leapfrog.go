@@ -0,0 +1,96 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package pcg
+
+import (
+	"math/bits"
+)
+
+// RngLeapfrog encapsulates a leapfrogged PCG XSL RR 128/64 PRNG. It produces
+// every p-th output of a Rng, starting at output k.
+//
+// RngLeapfrog values must be created with Rng.Leapfrog or RngStream.Leapfrog.
+// The zero value RngLeapfrog{} has no LCG multiplier and is not a valid
+// generator: its Uint64 method returns a constant value.
+//
+// Leapfrogging a LCG by p steps yields another LCG with multiplier a^p and
+// increment c*(a^p-1)/(a-1), so that RngLeapfrog is exactly as fast as Rng.
+//
+type RngLeapfrog struct {
+	LO uint64 // low 64 bits of 128 bits state
+	HI uint64 // high 64 bits of 128 bits state
+	// leapfrog LCG parameters
+	mul u128
+	inc u128
+	// k, p and increment of the canonical stream, used to re-seed.
+	k, p uint64
+	base u128
+}
+
+// Leapfrog returns a generator producing outputs k, k+p, k+2p, ... of rng,
+// where output 0 is the value that the next call to rng.Uint64 would return.
+// This allows p workers to consume a single canonical stream with results
+// independent of the number of workers. rng is left unchanged.
+//
+// Leapfrog panics if k >= p.
+//
+func (rng *Rng) Leapfrog(k, p uint64) *RngLeapfrog {
+	return newLeapfrog(u128{rng.HI, rng.LO}, u128{incHi, incLo}, k, p)
+}
+
+// Leapfrog is like Rng.Leapfrog, on the stream of rng.
+//
+func (rng *RngStream) Leapfrog(k, p uint64) *RngLeapfrog {
+	return newLeapfrog(u128{rng.HI, rng.LO}, rng.increment(), k, p)
+}
+
+func newLeapfrog(state, inc u128, k, p uint64) *RngLeapfrog {
+	if k >= p {
+		panic("pcg: Leapfrog with k >= p")
+	}
+	lf := &RngLeapfrog{k: k, p: p, base: inc}
+	lf.mul, lf.inc = lcgStride(u128{0, p}, u128{mulHi, mulLo}, lf.base)
+	lf.init(state)
+	return lf
+}
+
+// Seed seeds the canonical generator as Rng.Seed would, then sets the state
+// of rng to its k-th output.
+//
+func (rng *RngLeapfrog) Seed(seed int64) {
+	var src Rng
+	src.Seed(seed)
+	rng.init(u128{src.HI, src.LO})
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (rng *RngLeapfrog) Uint64() uint64 {
+	hi, lo := bits.Mul64(rng.LO, rng.mul.lo)
+	hi += rng.HI * rng.mul.lo
+	hi += rng.LO * rng.mul.hi
+
+	var c uint64
+	rng.LO, c = bits.Add64(lo, rng.inc.lo, 0)
+	rng.HI, _ = bits.Add64(hi, rng.inc.hi, c)
+
+	return bits.RotateLeft64(rng.HI^rng.LO, -int(rng.HI>>58))
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (rng *RngLeapfrog) Int63() int64 {
+	return int64(rng.Uint64() >> 1)
+}
+
+// init sets the state of rng from the state of the canonical generator.
+//
+func (rng *RngLeapfrog) init(state u128) {
+	// output k of the canonical generator is computed from state k+1. Since
+	// Uint64 steps before computing its output, move k+1-p steps forward.
+	delta := u128{0, rng.k + 1}.sub(u128{0, rng.p})
+	s := lcgAdvance(state, delta, u128{mulHi, mulLo}, rng.base)
+	rng.HI, rng.LO = s.hi, s.lo
+}
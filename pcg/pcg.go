@@ -3,12 +3,19 @@
 // can be found in the LICENSE file.
 
 /*
-Package pcg provides an implementation of the PCG XSL RR 128/64 pseudo random
-number generator.
+Package pcg provides implementations of PCG pseudo random number generators.
 
 Full details can be found at the PCG-Random website
-(http://www.pcg-random.org/). This version of the code provides a single
-member of the PCG family, PCG XSL RR 128/64 (LCG).
+(http://www.pcg-random.org/). This version of the code provides the following
+members of the PCG family:
+
+	Rng        PCG XSL RR 128/64 (LCG)
+	RngStream  PCG XSL RR 128/64 (LCG) with a selectable stream
+	RngDXSM    PCG DXSM 128/64 (cheap multiplier LCG)
+	RngXSHRR   PCG XSH RR 64/32 (LCG), aka pcg32
+	RngXSHRS   PCG XSH RS 64/32 (LCG)
+	RngRXSMXS  PCG RXS M XS 64/64 (LCG)
+	RngExt     extended PCG XSL RR 128/64 (LCG), aka pcg64_k32, pcg64_k1024, etc.
 
 Use of this algorithm is governed by a MIT-style license that can be found
 in the LICENSE-pcg file.
@@ -16,6 +23,7 @@ in the LICENSE-pcg file.
 package pcg
 
 import (
+	"math/big"
 	"math/bits"
 
 	"github.com/db47h/rand64/v3/splitmix64"
@@ -36,6 +44,9 @@ const (
 // 	Melissa E. O'Neill, Harvey Mudd College
 // 	https://www.cs.hmc.edu/tr/hmc-cs-2014-0905.pdf
 //
+// Rng uses the same default stream as the reference implementation. Use
+// RngStream to select another stream.
+//
 type Rng struct {
 	LO uint64 // low 64 bits of 128 bits state
 	HI uint64 // high 64 bits of 128 bits state
@@ -71,3 +82,154 @@ func (rng *Rng) Uint64() uint64 {
 func (rng *Rng) Int63() int64 {
 	return int64(rng.Uint64() >> 1)
 }
+
+// Advance advances the generator's state by n steps. It is equivalent to n
+// calls to Uint64, but runs in O(log n) time.
+//
+func (rng *Rng) Advance(n uint64) {
+	rng.advance(u128{0, n})
+}
+
+// Backstep moves the generator's state backwards by n steps, so that the next n
+// calls to Uint64 return the n values previously returned, in reverse order.
+//
+func (rng *Rng) Backstep(n uint64) {
+	rng.advance(u128{0, n}.neg())
+}
+
+// AdvanceBig advances the generator's state by n steps. If n is negative, the
+// state is moved backwards by -n steps. Since the generator's period is 2^128,
+// n is taken modulo 2^128.
+//
+func (rng *Rng) AdvanceBig(n *big.Int) {
+	rng.advance(u128FromBig(n))
+}
+
+// Distance returns the number of steps needed to reach the state of other
+// from the state of rng. The result is in the range [0, 2^128) and
+// rng.AdvanceBig(rng.Distance(other)) sets rng to the same state as other.
+//
+func (rng *Rng) Distance(other *Rng) *big.Int {
+	return lcgDistance(u128{rng.HI, rng.LO}, u128{other.HI, other.LO}, u128{mulHi, mulLo}, u128{incHi, incLo}).big()
+}
+
+func (rng *Rng) advance(delta u128) {
+	s := lcgAdvance(u128{rng.HI, rng.LO}, delta, u128{mulHi, mulLo}, u128{incHi, incLo})
+	rng.HI, rng.LO = s.hi, s.lo
+}
+
+// lcgSeed returns the initial state and increment of an LCG with multiplier mul
+// for the given seed and stream, the same way as pcg_setseq_128_srandom_r does.
+//
+func lcgSeed(seed, stream, mul u128) (state, inc u128) {
+	inc = stream.lsh1().or(u128{0, 1})
+	state = inc
+	state = state.add(seed).mul(mul).add(inc)
+	return state, inc
+}
+
+// lcgAdvance returns the state of the LCG with multiplier mul and increment
+// inc after delta steps from state.
+//
+func lcgAdvance(state, delta, mul, inc u128) u128 {
+	accMul, accInc := lcgStride(delta, mul, inc)
+	return accMul.mul(state).add(accInc)
+}
+
+// lcgStride returns the multiplier and increment of the LCG that performs delta
+// steps of the LCG with multiplier mul and increment inc at once. This is
+// Brown's algorithm from "Random Number Generation with Arbitrary Stride",
+// Transactions of the American Nuclear Society (Nov. 1994).
+//
+func lcgStride(delta, mul, inc u128) (accMul, accInc u128) {
+	accMul = u128{0, 1}
+	for !delta.isZero() {
+		if delta.lo&1 != 0 {
+			accMul = accMul.mul(mul)
+			accInc = accInc.mul(mul).add(inc)
+		}
+		inc = mul.add(u128{0, 1}).mul(inc)
+		mul = mul.mul(mul)
+		delta = delta.rsh1()
+	}
+	return accMul, accInc
+}
+
+// lcgDistance returns the number of steps needed to go from state from to
+// state to for the LCG with multiplier mul and increment inc. inc must be odd.
+//
+func lcgDistance(from, to, mul, inc u128) u128 {
+	bit, dist := u128{0, 1}, u128{}
+	for from != to {
+		if from.and(bit) != to.and(bit) {
+			from = from.mul(mul).add(inc)
+			dist = dist.or(bit)
+		}
+		bit = bit.lsh1()
+		inc = mul.add(u128{0, 1}).mul(inc)
+		mul = mul.mul(mul)
+	}
+	return dist
+}
+
+// u128 is a 128 bits unsigned integer.
+//
+type u128 struct {
+	hi, lo uint64
+}
+
+func u128FromBig(n *big.Int) u128 {
+	var m big.Int
+	m.Lsh(big.NewInt(1), 128)
+	m.Mod(n, &m)
+	lo := new(big.Int).And(&m, new(big.Int).SetUint64(^uint64(0))).Uint64()
+	return u128{m.Rsh(&m, 64).Uint64(), lo}
+}
+
+func (a u128) big() *big.Int {
+	n := new(big.Int).SetUint64(a.hi)
+	n.Lsh(n, 64)
+	return n.Or(n, new(big.Int).SetUint64(a.lo))
+}
+
+func (a u128) isZero() bool {
+	return a.hi|a.lo == 0
+}
+
+func (a u128) add(b u128) u128 {
+	lo, c := bits.Add64(a.lo, b.lo, 0)
+	hi, _ := bits.Add64(a.hi, b.hi, c)
+	return u128{hi, lo}
+}
+
+func (a u128) mul(b u128) u128 {
+	hi, lo := bits.Mul64(a.lo, b.lo)
+	hi += a.hi*b.lo + a.lo*b.hi
+	return u128{hi, lo}
+}
+
+func (a u128) sub(b u128) u128 {
+	lo, c := bits.Sub64(a.lo, b.lo, 0)
+	hi, _ := bits.Sub64(a.hi, b.hi, c)
+	return u128{hi, lo}
+}
+
+func (a u128) neg() u128 {
+	return u128{^a.hi, ^a.lo}.add(u128{0, 1})
+}
+
+func (a u128) and(b u128) u128 {
+	return u128{a.hi & b.hi, a.lo & b.lo}
+}
+
+func (a u128) or(b u128) u128 {
+	return u128{a.hi | b.hi, a.lo | b.lo}
+}
+
+func (a u128) lsh1() u128 {
+	return u128{a.hi<<1 | a.lo>>63, a.lo << 1}
+}
+
+func (a u128) rsh1() u128 {
+	return u128{a.hi >> 1, a.lo>>1 | a.hi<<63}
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package pcg
+
+import (
+	"math/bits"
+
+	"github.com/db47h/rand64/v3/splitmix64"
+)
+
+const (
+	mul64 = 6364136223846793005
+	inc64 = 1442695040888963407
+)
+
+// RngXSHRR encapsulates a PCG XSH RR 64/32 (LCG) PRNG. This is the pcg32
+// generator from the pcg_basic.c minimal C implementation.
+//
+// Its 64 bits state makes it much faster than Rng on 32 bits platforms. It
+// natively produces 32 bits values; Uint64 is composed from two draws.
+//
+// Like the other generators in this package, the zero value RngXSHRR{} is a
+// valid PRNG on the default stream.
+//
+type RngXSHRR struct {
+	State uint64 // 64 bits LCG state
+	// inc is the LCG increment XORed with the default increment.
+	inc uint64
+}
+
+// Seed uses the provided seed value to initialize the generator to a
+// deterministic state. The generator's stream is left unchanged.
+//
+func (rng *RngXSHRR) Seed(seed int64) {
+	rng.State = seed64(seed)
+}
+
+// SeedStream initializes the generator with the given initial state and
+// selects the stream identified by stream. Only the low 63 bits of the stream
+// are significant.
+//
+// This function behaves exactly like pcg32_srandom_r(rng, seed, stream) in
+// pcg_basic.c.
+//
+func (rng *RngXSHRR) SeedStream(seed, stream uint64) {
+	rng.State, rng.inc = lcgSeed64(seed, stream)
+}
+
+// Increment returns the LCG increment of the generator's current stream. The
+// increment is always odd.
+//
+func (rng *RngXSHRR) Increment() uint64 {
+	return rng.inc ^ inc64
+}
+
+// Uint32 returns a pseudo-random 32-bit value as a uint32.
+//
+func (rng *RngXSHRR) Uint32() uint32 {
+	s := rng.State
+	rng.State = s*mul64 + (rng.inc ^ inc64)
+	return bits.RotateLeft32(uint32((s>>18^s)>>27), -int(s>>59))
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64. The high 32 bits
+// are taken from the first of two successive calls to Uint32.
+//
+func (rng *RngXSHRR) Uint64() uint64 {
+	hi := rng.Uint32()
+	return uint64(hi)<<32 | uint64(rng.Uint32())
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (rng *RngXSHRR) Int63() int64 {
+	return int64(rng.Uint64() >> 1)
+}
+
+// RngXSHRS encapsulates a PCG XSH RS 64/32 (LCG) PRNG.
+//
+// It is slightly faster than RngXSHRR, with slightly weaker statistical
+// properties. It natively produces 32 bits values; Uint64 is composed from two
+// draws.
+//
+type RngXSHRS struct {
+	State uint64 // 64 bits LCG state
+	// inc is the LCG increment XORed with the default increment.
+	inc uint64
+}
+
+// Seed uses the provided seed value to initialize the generator to a
+// deterministic state. The generator's stream is left unchanged.
+//
+func (rng *RngXSHRS) Seed(seed int64) {
+	rng.State = seed64(seed)
+}
+
+// SeedStream initializes the generator with the given initial state and
+// selects the stream identified by stream. Only the low 63 bits of the stream
+// are significant.
+//
+// This function behaves exactly like pcg_setseq_64_srandom_r(rng, seed, stream)
+// in the C reference implementation.
+//
+func (rng *RngXSHRS) SeedStream(seed, stream uint64) {
+	rng.State, rng.inc = lcgSeed64(seed, stream)
+}
+
+// Increment returns the LCG increment of the generator's current stream. The
+// increment is always odd.
+//
+func (rng *RngXSHRS) Increment() uint64 {
+	return rng.inc ^ inc64
+}
+
+// Uint32 returns a pseudo-random 32-bit value as a uint32.
+//
+func (rng *RngXSHRS) Uint32() uint32 {
+	s := rng.State
+	rng.State = s*mul64 + (rng.inc ^ inc64)
+	return uint32((s >> 22 ^ s) >> (s>>61 + 22))
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64. The high 32 bits
+// are taken from the first of two successive calls to Uint32.
+//
+func (rng *RngXSHRS) Uint64() uint64 {
+	hi := rng.Uint32()
+	return uint64(hi)<<32 | uint64(rng.Uint32())
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (rng *RngXSHRS) Int63() int64 {
+	return int64(rng.Uint64() >> 1)
+}
+
+// RngRXSMXS encapsulates a PCG RXS M XS 64/64 (LCG) PRNG.
+//
+// Its output function is a permutation of the 64 bits state, so that no 64 bits
+// value is ever repeated within a period. This makes it fail tests where
+// repeats are expected, like the birthday test.
+//
+type RngRXSMXS struct {
+	State uint64 // 64 bits LCG state
+	// inc is the LCG increment XORed with the default increment.
+	inc uint64
+}
+
+// Seed uses the provided seed value to initialize the generator to a
+// deterministic state. The generator's stream is left unchanged.
+//
+func (rng *RngRXSMXS) Seed(seed int64) {
+	rng.State = seed64(seed)
+}
+
+// SeedStream initializes the generator with the given initial state and
+// selects the stream identified by stream. Only the low 63 bits of the stream
+// are significant.
+//
+// This function behaves exactly like pcg_setseq_64_srandom_r(rng, seed, stream)
+// in the C reference implementation.
+//
+func (rng *RngRXSMXS) SeedStream(seed, stream uint64) {
+	rng.State, rng.inc = lcgSeed64(seed, stream)
+}
+
+// Increment returns the LCG increment of the generator's current stream. The
+// increment is always odd.
+//
+func (rng *RngRXSMXS) Increment() uint64 {
+	return rng.inc ^ inc64
+}
+
+// Uint32 returns the high 32 bits of a pseudo-random 64-bit value as a uint32.
+//
+func (rng *RngRXSMXS) Uint32() uint32 {
+	return uint32(rng.Uint64() >> 32)
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (rng *RngRXSMXS) Uint64() uint64 {
+	s := rng.State
+	rng.State = s*mul64 + (rng.inc ^ inc64)
+	return rxsmxs64(s)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (rng *RngRXSMXS) Int63() int64 {
+	return int64(rng.Uint64() >> 1)
+}
+
+// rxsmxs64 is the RXS M XS 64/64 output function.
+//
+func rxsmxs64(s uint64) uint64 {
+	w := (s>>(s>>59+5) ^ s) * 12605985483714917081
+	return w>>43 ^ w
+}
+
+// seed64 returns a 64 bits state for the given seed. Seeding through a
+// splitmix64 generator ensures that close seeds give unrelated states.
+//
+func seed64(seed int64) uint64 {
+	src := splitmix64.Rng{}
+	src.Seed(seed)
+	return src.Uint64()
+}
+
+// lcgSeed64 returns the initial state and XORed increment of a 64 bits LCG for
+// the given seed and stream, the same way as pcg32_srandom_r does.
+//
+func lcgSeed64(seed, stream uint64) (state, incx uint64) {
+	inc := stream<<1 | 1
+	state = (inc+seed)*mul64 + inc
+	return state, inc ^ inc64
+}
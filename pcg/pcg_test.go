@@ -0,0 +1,298 @@
+package pcg_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/db47h/rand64/v3/pcg"
+)
+
+const (
+	SEED1 = 1387366483214
+)
+
+func TestRng_Advance(t *testing.T) {
+	var want [1000]uint64
+	rng := pcg.Rng{}
+	rng.Seed(SEED1)
+	for i := range want {
+		want[i] = rng.Uint64()
+	}
+	for _, n := range []uint64{0, 1, 2, 63, 64, 65, 500, 999} {
+		rng.Seed(SEED1)
+		rng.Advance(n)
+		if v := rng.Uint64(); v != want[n] {
+			t.Fatalf("Advance(%d): expected %d, got %d", n, want[n], v)
+		}
+		rng.Backstep(n + 1)
+		if v := rng.Uint64(); v != want[0] {
+			t.Fatalf("Backstep(%d): expected %d, got %d", n+1, want[0], v)
+		}
+		rng.AdvanceBig(big.NewInt(int64(n)))
+		rng.AdvanceBig(big.NewInt(-int64(n)))
+		if v := rng.Uint64(); v != want[1] {
+			t.Fatalf("AdvanceBig(%d): expected %d, got %d", n, want[1], v)
+		}
+	}
+}
+
+func TestRng_Distance(t *testing.T) {
+	a, b := pcg.Rng{}, pcg.Rng{}
+	a.Seed(SEED1)
+	b.Seed(SEED1)
+	if d := a.Distance(&b); d.Sign() != 0 {
+		t.Fatalf("expected 0, got %v", d)
+	}
+	for _, n := range []string{
+		"1",
+		"12345678901234567890",
+		"340282366920938463463374607431768211455", // 2^128-1
+		"170141183460469231731687303715884105728", // 2^127
+	} {
+		d, _ := new(big.Int).SetString(n, 10)
+		b = a
+		b.AdvanceBig(d)
+		if got := a.Distance(&b); got.Cmp(d) != 0 {
+			t.Fatalf("expected %v, got %v", d, got)
+		}
+		if got := b.Distance(&a); got.Add(got, d).Cmp(new(big.Int).Lsh(big.NewInt(1), 128)) != 0 {
+			t.Fatalf("expected 2^128-%v, got %v", d, got)
+		}
+		c := a
+		c.AdvanceBig(c.Distance(&b))
+		if c != b {
+			t.Fatalf("AdvanceBig(Distance()): expected %v, got %v", b, c)
+		}
+	}
+}
+
+// Expected values from pcg64_srandom_r in the C reference implementation.
+func TestRngStream(t *testing.T) {
+	rng := pcg.RngStream{}
+	// the default stream is the one of Rng
+	ref := pcg.Rng{}
+	rng.Seed(SEED1)
+	ref.Seed(SEED1)
+	for i := 0; i < 3; i++ {
+		if v, w := rng.Uint64(), ref.Uint64(); v != w {
+			t.Fatalf("default stream: expected %d, got %d", w, v)
+		}
+	}
+	if hi, lo := rng.Increment(); hi != 6364136223846793005 || lo != 1442695040888963407 {
+		t.Fatalf("wrong default increment %x%016x", hi, lo)
+	}
+	rng.SeedStream(42, 54)
+	if hi, lo := rng.Increment(); hi != 0 || lo != 109 {
+		t.Fatalf("expected increment 109, got %x%016x", hi, lo)
+	}
+	for _, v := range []uint64{
+		0x86b1da1d72062b68, 0x1304aa46c9853d39, 0xa3670e9e0dd50358,
+		0xf9090e529a7dae00, 0xc85b9fd837996f2c, 0x606121f8e3919196,
+	} {
+		if n := rng.Uint64(); n != v {
+			t.Fatalf("Expected %X, got %X", v, n)
+		}
+	}
+	rng.SeedStream128(0x0123456789abcdef, 0xfedcba9876543210, 0xdeadbeefcafef00d, 0x0f1e2d3c4b5a6978)
+	for _, v := range []uint64{
+		12459178843211285485, 14829634871318612759, 17651975927611826298, 5542568436152641854,
+	} {
+		if n := rng.Uint64(); n != v {
+			t.Fatalf("Expected %d, got %d", v, n)
+		}
+	}
+	// Advance and Distance on a non-default stream
+	a := rng
+	rng.Advance(12345)
+	if d := a.Distance(&rng); d.Cmp(big.NewInt(12345)) != 0 {
+		t.Fatalf("Expected distance 12345, got %v", d)
+	}
+}
+
+// Expected values from pcg_cm_srandom_r and pcg_cm_random_r in the C reference
+// implementation.
+func TestRngDXSM(t *testing.T) {
+	rng := pcg.RngDXSM{}
+	for _, v := range []uint64{0, 4107282207882862730, 12465256434652918137} {
+		if n := rng.Uint64(); n != v {
+			t.Fatalf("Expected %d, got %d", v, n)
+		}
+	}
+	rng.SeedStream(42, 54)
+	for _, v := range []uint64{
+		0xf0847c9518bddb90, 0x8e7d5f5514ba8aaa, 0x86fbd36f8028f6fd,
+		0x8d14b6edbe9f740a, 0xa85b2896c7cad55d, 0x8ca3894a1d9227bb,
+	} {
+		if n := rng.Uint64(); n != v {
+			t.Fatalf("Expected %X, got %X", v, n)
+		}
+	}
+	rng.SeedStream128(0x0123456789abcdef, 0xfedcba9876543210, 0xdeadbeefcafef00d, 0x0f1e2d3c4b5a6978)
+	for _, v := range []uint64{
+		11560174954046632528, 12248987938836481213, 6934321015232516759, 8434225095889445032,
+	} {
+		if n := rng.Uint64(); n != v {
+			t.Fatalf("Expected %d, got %d", v, n)
+		}
+	}
+}
+
+func TestRngDXSM_Advance(t *testing.T) {
+	var want [1000]uint64
+	rng := pcg.RngDXSM{}
+	rng.SeedStream(SEED1, 1)
+	for i := range want {
+		want[i] = rng.Uint64()
+	}
+	for _, n := range []uint64{0, 1, 2, 63, 64, 65, 500, 999} {
+		rng.SeedStream(SEED1, 1)
+		a := rng
+		rng.Advance(n)
+		if d := a.Distance(&rng); d.Cmp(new(big.Int).SetUint64(n)) != 0 {
+			t.Fatalf("Distance: expected %d, got %v", n, d)
+		}
+		if v := rng.Uint64(); v != want[n] {
+			t.Fatalf("Advance(%d): expected %d, got %d", n, want[n], v)
+		}
+		rng.Backstep(n + 1)
+		if v := rng.Uint64(); v != want[0] {
+			t.Fatalf("Backstep(%d): expected %d, got %d", n+1, want[0], v)
+		}
+	}
+}
+
+// Expected values from pcg32_srandom_r and pcg32_random_r in pcg_basic.c and
+// their XSH RS and RXS M XS counterparts in the C reference implementation.
+func TestRng64(t *testing.T) {
+	var xshrr pcg.RngXSHRR
+	for _, v := range []uint32{0x00000000, 0x602bf3fd, 0xe823a24e} {
+		if n := xshrr.Uint32(); n != v {
+			t.Fatalf("RngXSHRR{}: expected %X, got %X", v, n)
+		}
+	}
+	xshrr.SeedStream(42, 54)
+	if inc := xshrr.Increment(); inc != 109 {
+		t.Fatalf("expected increment 109, got %d", inc)
+	}
+	for _, v := range []uint32{0xa15c02b7, 0x7b47f409, 0xba1d3330, 0x83d2f293, 0xbfa4784b, 0xcbed606e} {
+		if n := xshrr.Uint32(); n != v {
+			t.Fatalf("RngXSHRR: expected %X, got %X", v, n)
+		}
+	}
+	xshrr.SeedStream(42, 54)
+	if n := xshrr.Uint64(); n != 0xa15c02b77b47f409 {
+		t.Fatalf("RngXSHRR: expected %X, got %X", uint64(0xa15c02b77b47f409), n)
+	}
+
+	var xshrs pcg.RngXSHRS
+	xshrs.SeedStream(42, 54)
+	for _, v := range []uint32{0x5c1b65c0, 0x8ffceb31, 0xcccad075, 0xb83cdfc6, 0x5dfce9ca, 0xc0d524ec} {
+		if n := xshrs.Uint32(); n != v {
+			t.Fatalf("RngXSHRS: expected %X, got %X", v, n)
+		}
+	}
+
+	var rxsmxs pcg.RngRXSMXS
+	rxsmxs.SeedStream(42, 54)
+	for _, v := range []uint64{
+		0xe1cbc180b69606bb, 0x6573bce7abaee684, 0xc744f07442006076,
+		0x9e9f98ccbd60b8fc, 0xde693821ee9629ae, 0x263cc2cdc66ebc25,
+	} {
+		if n := rxsmxs.Uint64(); n != v {
+			t.Fatalf("RngRXSMXS: expected %X, got %X", v, n)
+		}
+	}
+}
+
+// Regression values. They were computed with a standalone C++ transcription of
+// the extended engine of pcg_random.hpp, not with pcg_random.hpp itself, and
+// should be checked against pcg64_k32 and pcg64_k1024 from the C++ library.
+func TestRngExt(t *testing.T) {
+	rng := pcg.NewExt(10)
+	for _, v := range []uint64{8269303586148613052, 13880561190893172669, 356522898681002235, 15708926673483292903} {
+		if n := rng.Uint64(); n != v {
+			t.Fatalf("NewExt(10): expected %d, got %d", v, n)
+		}
+	}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("NewExt(64): expected panic")
+			}
+		}()
+		pcg.NewExt(64)
+	}()
+	tests := []struct {
+		tablePow2 uint
+		first     [4]uint64
+		xor, next uint64 // xor of the next 1000000 values, then next value.
+	}{
+		{5, [4]uint64{3299924198840903899, 11909880908351458342, 9225963563113056089, 9151159608949830347},
+			14850903892041417558, 12027645834385536244},
+		{10, [4]uint64{10202630832295581652, 4947230888669860575, 4184923643069557398, 13987563802521650773},
+			15605901508399871109, 10872108934928960504},
+	}
+	for _, tt := range tests {
+		rng := pcg.NewExt(tt.tablePow2)
+		rng.SeedStream(42, 54)
+		for _, v := range tt.first {
+			if n := rng.Uint64(); n != v {
+				t.Fatalf("NewExt(%d): expected %d, got %d", tt.tablePow2, v, n)
+			}
+		}
+		if testing.Short() {
+			continue
+		}
+		var x uint64
+		for i := 0; i < 1000000; i++ {
+			x ^= rng.Uint64()
+		}
+		if x != tt.xor {
+			t.Fatalf("NewExt(%d): expected xor %d, got %d", tt.tablePow2, tt.xor, x)
+		}
+		if n := rng.Uint64(); n != tt.next {
+			t.Fatalf("NewExt(%d): expected %d, got %d", tt.tablePow2, tt.next, n)
+		}
+	}
+}
+
+func TestRng_Leapfrog(t *testing.T) {
+	var rng pcg.Rng
+	rng.Seed(SEED1)
+	lf := rng.Leapfrog(2, 5)
+	rng.Advance(2)
+	for i := 0; i < 10; i++ {
+		if v, w := lf.Uint64(), rng.Uint64(); v != w {
+			t.Fatalf("Leapfrog(2, 5): output %d: expected %d, got %d", 2+5*i, w, v)
+		}
+		rng.Advance(4)
+	}
+}
+
+func TestRngStream_Leapfrog(t *testing.T) {
+	const p = 7
+	var want [700]uint64
+	rng := pcg.RngStream{}
+	rng.SeedStream(SEED1, 3)
+	base := rng
+	for i := range want {
+		want[i] = rng.Uint64()
+	}
+	for k := uint64(0); k < p; k++ {
+		lf := base.Leapfrog(k, p)
+		for i := k; i < uint64(len(want)); i += p {
+			if v := lf.Uint64(); v != want[i] {
+				t.Fatalf("Leapfrog(%d, %d): output %d: expected %d, got %d", k, p, i, want[i], v)
+			}
+		}
+	}
+	// Seed must use the same canonical stream.
+	lf := base.Leapfrog(3, p)
+	lf.Seed(SEED1)
+	rng = base
+	rng.Seed(SEED1)
+	rng.Advance(3)
+	if v, w := lf.Uint64(), rng.Uint64(); v != w {
+		t.Fatalf("Seed: expected %d, got %d", w, v)
+	}
+}
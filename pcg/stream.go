@@ -0,0 +1,132 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package pcg
+
+import (
+	"math/big"
+	"math/bits"
+)
+
+// RngStream encapsulates a PCG XSL RR 128/64 (LCG) PRNG with a selectable
+// stream. It produces the same values as Rng, except that the generator
+// supports 2^127 distinct streams, selected by the LCG increment.
+//
+// The zero value RngStream{} uses the same default increment as Rng and the
+// reference implementation. Use SeedStream to select another stream.
+//
+type RngStream struct {
+	LO uint64 // low 64 bits of 128 bits state
+	HI uint64 // high 64 bits of 128 bits state
+	// inc is the LCG increment XORed with the default increment. This allows
+	// the use of a RngStream{} struct literal as a valid PRNG on the default
+	// stream.
+	inc u128
+}
+
+// Seed uses the provided seed value to initialize the generator to a
+// deterministic state, like Rng.Seed. The generator's stream is left
+// unchanged.
+//
+func (rng *RngStream) Seed(seed int64) {
+	var src Rng
+	src.Seed(seed)
+	rng.LO, rng.HI = src.LO, src.HI
+}
+
+// SeedStream initializes the generator with the given initial state and
+// selects the stream identified by stream. Generators seeded with different
+// stream values produce distinct sequences, even when seeded with the same
+// initial state.
+//
+// This function behaves exactly like pcg64_srandom_r(rng, seed, stream) in the
+// C reference implementation.
+//
+func (rng *RngStream) SeedStream(seed, stream uint64) {
+	rng.SeedStream128(0, seed, 0, stream)
+}
+
+// SeedStream128 is like SeedStream, but takes full 128 bits initial state and
+// stream values, given as pairs of high and low 64 bits words. Only the low
+// 127 bits of the stream are significant.
+//
+func (rng *RngStream) SeedStream128(seedHi, seedLo, streamHi, streamLo uint64) {
+	state, inc := lcgSeed(u128{seedHi, seedLo}, u128{streamHi, streamLo}, u128{mulHi, mulLo})
+	rng.HI, rng.LO = state.hi, state.lo
+	rng.inc = u128{inc.hi ^ incHi, inc.lo ^ incLo}
+}
+
+// Increment returns the LCG increment of the generator's current stream as a
+// pair of high and low 64 bits words. The increment is always odd.
+//
+func (rng *RngStream) Increment() (hi, lo uint64) {
+	inc := rng.increment()
+	return inc.hi, inc.lo
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (rng *RngStream) Uint64() uint64 {
+	hi, lo := bits.Mul64(rng.LO, mulLo)
+	hi += rng.HI * mulLo
+	hi += rng.LO * mulHi
+
+	var c uint64
+	rng.LO, c = bits.Add64(lo, rng.inc.lo^incLo, 0)
+	rng.HI, _ = bits.Add64(hi, rng.inc.hi^incHi, c)
+
+	// return hi^lo rotated right by high 6 bits of 128 bits state
+	return bits.RotateLeft64(rng.HI^rng.LO, -int(rng.HI>>58))
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (rng *RngStream) Int63() int64 {
+	return int64(rng.Uint64() >> 1)
+}
+
+// Advance advances the generator's state by n steps. It is equivalent to n
+// calls to Uint64, but runs in O(log n) time.
+//
+func (rng *RngStream) Advance(n uint64) {
+	rng.advance(u128{0, n})
+}
+
+// Backstep moves the generator's state backwards by n steps, so that the next n
+// calls to Uint64 return the n values previously returned, in reverse order.
+//
+func (rng *RngStream) Backstep(n uint64) {
+	rng.advance(u128{0, n}.neg())
+}
+
+// AdvanceBig advances the generator's state by n steps. If n is negative, the
+// state is moved backwards by -n steps. Since the generator's period is 2^128,
+// n is taken modulo 2^128.
+//
+func (rng *RngStream) AdvanceBig(n *big.Int) {
+	rng.advance(u128FromBig(n))
+}
+
+// Distance returns the number of steps needed to reach the state of other
+// from the state of rng. The result is in the range [0, 2^128) and
+// rng.AdvanceBig(rng.Distance(other)) sets rng to the same state as other.
+//
+// Generators on different streams never share any state, so Distance panics
+// if rng and other do not use the same stream.
+//
+func (rng *RngStream) Distance(other *RngStream) *big.Int {
+	if rng.inc != other.inc {
+		panic("pcg: Distance between generators on different streams")
+	}
+	return lcgDistance(u128{rng.HI, rng.LO}, u128{other.HI, other.LO}, u128{mulHi, mulLo}, rng.increment()).big()
+}
+
+func (rng *RngStream) advance(delta u128) {
+	s := lcgAdvance(u128{rng.HI, rng.LO}, delta, u128{mulHi, mulLo}, rng.increment())
+	rng.HI, rng.LO = s.hi, s.lo
+}
+
+func (rng *RngStream) increment() u128 {
+	return u128{rng.inc.hi ^ incHi, rng.inc.lo ^ incLo}
+}
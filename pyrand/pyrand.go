@@ -0,0 +1,210 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+/*
+Package pyrand provides a source of pseudo-random numbers that is bit-exact
+compatible with Python's random module.
+
+Python's random.Random uses the 32-bit Mersenne Twister (mt19937.Rng32), seeded
+with init_by_array from the absolute value of an integer seed split into 32 bits
+words. Given the same seed, the methods of Rand return the same values as their
+Python counterparts:
+
+	Python                        Go
+	random.seed(n)                r.Seed(n)
+	random.random()               r.Random()
+	random.getrandbits(k)         r.Getrandbits(k)    // 0 <= k <= 64
+	random.randrange(n)           r.Randbelow(n)
+	random.randrange(a, b, step)  r.Randrange(a, b, step)
+	random.randint(a, b)          r.Randint(a, b)
+	random.shuffle(x)             r.Shuffle(len(x), swap)
+	random.choice(seq)            seq[r.Choice(len(seq))]
+
+Note that Python integers have arbitrary precision while this package is
+limited to 64 bits integers.
+*/
+package pyrand
+
+import (
+	"math/bits"
+
+	"github.com/db47h/rand64/v3/mt19937"
+)
+
+// Rand reproduces the sequences generated by Python's random.Random. It
+// implements rand.Source64 so that it can be used with rand.New, but only the
+// values returned by its own methods are Python compatible.
+//
+// The zero value Rand{} is seeded like mt19937.Rng32{}, which has no Python
+// equivalent. Use New or Seed.
+//
+type Rand struct {
+	mt mt19937.Rng32
+}
+
+// New returns a new Rand seeded like random.Random(seed).
+//
+func New(seed int64) *Rand {
+	r := &Rand{}
+	r.Seed(seed)
+	return r
+}
+
+// Seed initializes the generator like random.seed(seed). As in Python, the
+// sign of seed is ignored.
+//
+func (r *Rand) Seed(seed int64) {
+	n := uint64(seed)
+	if seed < 0 {
+		n = -n
+	}
+	r.SeedUint64(n)
+}
+
+// SeedUint64 initializes the generator like random.seed(seed).
+//
+func (r *Rand) SeedUint64(seed uint64) {
+	key := []uint32{uint32(seed)}
+	if hi := uint32(seed >> 32); hi != 0 {
+		key = append(key, hi)
+	}
+	r.mt.SeedFromSlice(key)
+}
+
+// Uint32 returns a pseudo-random 32-bit value as a uint32. This is the same
+// as random.getrandbits(32).
+//
+func (r *Rand) Uint32() uint32 {
+	return r.mt.Uint32()
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64. This is the same
+// as random.getrandbits(64).
+//
+func (r *Rand) Uint64() uint64 {
+	return r.Getrandbits(64)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64. This
+// is the same as random.getrandbits(63).
+//
+func (r *Rand) Int63() int64 {
+	return int64(r.Getrandbits(63))
+}
+
+// Random returns, as a float64, a pseudo-random number in [0.0,1.0) with 53
+// bits of precision. This is the same as random.random().
+//
+func (r *Rand) Random() float64 {
+	a := r.mt.Uint32() >> 5
+	b := r.mt.Uint32() >> 6
+	return (float64(a)*67108864.0 + float64(b)) * (1.0 / 9007199254740992.0)
+}
+
+// Getrandbits returns a non-negative integer with k random bits. This is the
+// same as random.getrandbits(k). Python fills the result 32 bits at a time,
+// starting with the least significant word, and the last word is taken from
+// the most significant bits of a 32-bit draw.
+//
+// Getrandbits panics if k < 0 or k > 64.
+//
+func (r *Rand) Getrandbits(k int) uint64 {
+	if k < 0 || k > 64 {
+		panic("pyrand: invalid argument to Getrandbits")
+	}
+	if k == 0 {
+		return 0
+	}
+	if k <= 32 {
+		return uint64(r.mt.Uint32() >> uint(32-k))
+	}
+	lo := uint64(r.mt.Uint32())
+	hi := uint64(r.mt.Uint32() >> uint(64-k))
+	return hi<<32 | lo
+}
+
+// Randbelow returns a random integer in [0, n). This is the same as
+// random.randrange(n) and random._randbelow(n). It uses rejection sampling
+// with the minimum number of bits.
+//
+// Randbelow panics if n <= 0.
+//
+func (r *Rand) Randbelow(n int) int {
+	if n <= 0 {
+		panic("pyrand: invalid argument to Randbelow")
+	}
+	k := bits.Len64(uint64(n))
+	v := r.Getrandbits(k)
+	for v >= uint64(n) {
+		v = r.Getrandbits(k)
+	}
+	return int(v)
+}
+
+// Randrange returns a randomly selected element from range(start, stop,
+// step). This is the same as random.randrange(start, stop, step).
+//
+// Randrange panics if the range is empty or if step is 0.
+//
+func (r *Rand) Randrange(start, stop, step int) int {
+	width := stop - start
+	if step == 1 {
+		if width > 0 {
+			return start + r.Randbelow(width)
+		}
+		panic("pyrand: empty range for Randrange")
+	}
+	var n int
+	switch {
+	case step > 0:
+		n = floorDiv(width+step-1, step)
+	case step < 0:
+		n = floorDiv(width+step+1, step)
+	default:
+		panic("pyrand: zero step for Randrange")
+	}
+	if n <= 0 {
+		panic("pyrand: empty range for Randrange")
+	}
+	return start + step*r.Randbelow(n)
+}
+
+// Randint returns a random integer in [a, b], including both end points. This
+// is the same as random.randint(a, b).
+//
+func (r *Rand) Randint(a, b int) int {
+	return r.Randrange(a, b+1, 1)
+}
+
+// Shuffle pseudo-randomizes the order of n elements like random.shuffle.
+// swap swaps the elements with indexes i and j.
+//
+func (r *Rand) Shuffle(n int, swap func(i, j int)) {
+	for i := n - 1; i > 0; i-- {
+		j := r.Randbelow(i + 1)
+		swap(i, j)
+	}
+}
+
+// Choice returns the index of a random element of a sequence of length n, so
+// that seq[r.Choice(len(seq))] is the same as random.choice(seq).
+//
+// Choice panics if n <= 0.
+//
+func (r *Rand) Choice(n int) int {
+	if n <= 0 {
+		panic("pyrand: cannot choose from an empty sequence")
+	}
+	return r.Randbelow(n)
+}
+
+// floorDiv returns a/b rounded towards negative infinity, like Python's //.
+//
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
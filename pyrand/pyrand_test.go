@@ -0,0 +1,96 @@
+package pyrand_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/db47h/rand64/v3/pyrand"
+)
+
+// Expected values generated with CPython 3.11.
+
+func Example() {
+	r := pyrand.New(42)
+	// random.random()
+	for i := 0; i < 3; i++ {
+		fmt.Printf(" %v", r.Random())
+	}
+	fmt.Println()
+	// random.shuffle(l)
+	r.Seed(7)
+	l := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	r.Shuffle(len(l), func(i, j int) { l[i], l[j] = l[j], l[i] })
+	fmt.Println(l)
+	// random.choice(s)
+	r.Seed(7)
+	s := "abcdefgh"
+	for i := 0; i < 8; i++ {
+		fmt.Printf("%c", s[r.Choice(len(s))])
+	}
+	fmt.Println()
+
+	// Output:
+	//  0.6394267984578837 0.025010755222666936 0.27502931836911926
+	// [8 3 1 4 7 0 9 6 2 5]
+	// fcgabbfa
+}
+
+func TestRand_Seed(t *testing.T) {
+	tests := []struct {
+		seed int64
+		want float64
+	}{
+		{42, 0.6394267984578837},
+		{-42, 0.6394267984578837},
+		{-1 << 63, 0.5534639983914199},
+		{0, 0.8444218515250481},
+	}
+	for _, tt := range tests {
+		r := pyrand.New(tt.seed)
+		if v := r.Random(); v != tt.want {
+			t.Fatalf("seed %d: expected %v, got %v", tt.seed, tt.want, v)
+		}
+	}
+}
+
+func TestRand_Getrandbits(t *testing.T) {
+	r := pyrand.New(42)
+	for i, k := range []int{1, 7, 32, 33, 63, 64} {
+		want := []uint64{1, 14, 107420369, 3184935163, 2058755736454904720, 13585496030504862185}[i]
+		if v := r.Getrandbits(k); v != want {
+			t.Fatalf("Getrandbits(%d): expected %d, got %d", k, want, v)
+		}
+	}
+	r.Seed(99)
+	if v := r.Uint64(); v != 7023646418445998953 {
+		t.Fatalf("Uint64: expected 7023646418445998953, got %d", v)
+	}
+	if v := r.Int63(); v != 5528849884183536894 {
+		t.Fatalf("Int63: expected 5528849884183536894, got %d", v)
+	}
+}
+
+func TestRand_Randrange(t *testing.T) {
+	r := pyrand.New(12345678901234)
+	for _, want := range []int{0, 8, 2, 6, 8, 6, 7, 6, 3, 2} {
+		if v := r.Randbelow(10); v != want {
+			t.Fatalf("Randbelow(10): expected %d, got %d", want, v)
+		}
+	}
+	r.Seed(7)
+	for _, want := range []int{30, 9, 37, 65, -5} {
+		if v := r.Randrange(-5, 100, 7); v != want {
+			t.Fatalf("Randrange(-5, 100, 7): expected %d, got %d", want, v)
+		}
+	}
+	for _, want := range []int{88, -2, 82, 31, 91} {
+		if v := r.Randrange(100, -5, -3); v != want {
+			t.Fatalf("Randrange(100, -5, -3): expected %d, got %d", want, v)
+		}
+	}
+	for _, want := range []int{5, 2, 1, 1, 4, 4, 1, 2, 1, 5} {
+		if v := r.Randint(1, 6); v != want {
+			t.Fatalf("Randint(1, 6): expected %d, got %d", want, v)
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/db47h/rand64/v3/pcg"
 	"github.com/db47h/rand64/v3/splitmix64"
 	"github.com/db47h/rand64/v3/xoroshiro"
+	"github.com/db47h/rand64/v3/xorshift"
 	"github.com/db47h/rand64/v3/xoshiro"
 )
 
@@ -53,6 +54,14 @@ func BenchmarkXoshiro256starstar(b *testing.B) {
 	}
 }
 
+func BenchmarkXoshiro256plusplus(b *testing.B) {
+	s := rand.Source64(&xoshiro.Rng256PP{})
+	s.Seed(SEED1)
+	for i := 0; i < b.N; i++ {
+		_ = s.Uint64()
+	}
+}
+
 func BenchmarkXoshiro256plus(b *testing.B) {
 	s := rand.Source64(&xoshiro.Rng256P{})
 	s.Seed(SEED1)
@@ -69,6 +78,38 @@ func BenchmarkPCG(b *testing.B) {
 	}
 }
 
+func BenchmarkPCGDXSM(b *testing.B) {
+	s := rand.Source64(&pcg.RngDXSM{})
+	s.Seed(SEED1)
+	for i := 0; i < b.N; i++ {
+		_ = s.Uint64()
+	}
+}
+
+func BenchmarkPCGXSHRR(b *testing.B) {
+	s := rand.Source64(&pcg.RngXSHRR{})
+	s.Seed(SEED1)
+	for i := 0; i < b.N; i++ {
+		_ = s.Uint64()
+	}
+}
+
+func BenchmarkPCGXSHRS(b *testing.B) {
+	s := rand.Source64(&pcg.RngXSHRS{})
+	s.Seed(SEED1)
+	for i := 0; i < b.N; i++ {
+		_ = s.Uint64()
+	}
+}
+
+func BenchmarkPCGRXSMXS(b *testing.B) {
+	s := rand.Source64(&pcg.RngRXSMXS{})
+	s.Seed(SEED1)
+	for i := 0; i < b.N; i++ {
+		_ = s.Uint64()
+	}
+}
+
 func BenchmarkSplitmix64(b *testing.B) {
 	s := rand.Source64(&splitmix64.Rng{})
 	s.Seed(SEED1)
@@ -77,6 +118,16 @@ func BenchmarkSplitmix64(b *testing.B) {
 	}
 }
 
+func BenchmarkSplitmix64Atomic(b *testing.B) {
+	s := rand.Source64(&splitmix64.AtomicRng{})
+	s.Seed(SEED1)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = s.Uint64()
+		}
+	})
+}
+
 func BenchmarkMt19937(b *testing.B) {
 	s := rand.Source64(&mt19937.Rng{})
 	s.Seed(SEED1)
@@ -85,6 +136,22 @@ func BenchmarkMt19937(b *testing.B) {
 	}
 }
 
+func BenchmarkMt19937_32(b *testing.B) {
+	s := rand.Source64(&mt19937.Rng32{})
+	s.Seed(SEED1)
+	for i := 0; i < b.N; i++ {
+		_ = s.Uint64()
+	}
+}
+
+func BenchmarkXorshift128P(b *testing.B) {
+	s := rand.Source64(&xorshift.Rng128P{})
+	s.Seed(SEED1)
+	for i := 0; i < b.N; i++ {
+		_ = s.Uint64()
+	}
+}
+
 func BenchmarkGoRand(b *testing.B) {
 	s := rand.NewSource(SEED1).(rand.Source64)
 	for i := 0; i < b.N; i++ {
@@ -0,0 +1,36 @@
+#include <stdint.h>
+#include <stdio.h>
+
+extern uint64_t sm64;
+extern uint64_t sm64_next(void);
+
+extern uint64_t s[STATE];
+extern uint64_t next(void);
+extern void jump(void);
+extern void long_jump(void);
+
+#define SEED1 1387366483214
+
+int main()
+{
+	int i;
+	sm64 = SEED1;
+	for (i = 0; i < STATE; i++)
+	{
+		s[i] = sm64_next();
+	}
+
+	jump();
+	for (i = 0; i < 4; i++)
+	{
+		printf(" %lu", next());
+	}
+	puts("");
+	long_jump();
+	for (i = 0; i < 4; i++)
+	{
+		printf(" %lu", next());
+	}
+	puts("");
+	return 0;
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package splitmix64
+
+import (
+	"sync/atomic"
+)
+
+// AtomicRng is a splitmix64 PRNG that is safe for concurrent use by multiple
+// goroutines without locking: its state is advanced with a single atomic add,
+// and the output is mixed locally.
+//
+// When used by a single goroutine, AtomicRng produces the same sequence as
+// Rng. With concurrent callers, each value of the sequence is returned exactly
+// once, but the order in which goroutines receive them is unspecified.
+//
+// On 32 bits platforms, an AtomicRng must be 64 bits aligned. This is
+// guaranteed if it is allocated on its own or is the first field of an
+// allocated struct.
+//
+type AtomicRng struct {
+	state uint64
+}
+
+// Seed uses the provided seed value to initialize the generator to a
+// deterministic state.
+//
+func (rng *AtomicRng) Seed(seed int64) {
+	atomic.StoreUint64(&rng.state, uint64(seed))
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (rng *AtomicRng) Uint64() uint64 {
+	return mix64(atomic.AddUint64(&rng.state, goldenGamma))
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (rng *AtomicRng) Int63() int64 {
+	return int64(rng.Uint64() >> 1)
+}
@@ -5,7 +5,10 @@
 /*
 Package splitmix64 implements a 64 bit SplittableRandom PRNG.
 
-This is a fixed-increment version of Java 8's SplittableRandom generator.
+Rng is a fixed-increment version of Java 8's SplittableRandom generator, while
+SplittableRng implements its full semantics, including splitting, and its
+NextLong, NextInt, NextIntn and NextDouble methods return the same values as
+the methods of the same name in Java.
 
 Period: 2^64. State size: 64 bits.
 
@@ -17,6 +20,11 @@ and xorshift1024* generators to initialize their state arrays.
 */
 package splitmix64
 
+// goldenGamma is the odd integer closest to 2^64/phi, phi being the golden
+// ratio. It is the fixed increment of Rng.
+//
+const goldenGamma = 0x9E3779B97F4A7C15
+
 // Rng encapsulates a splitmix64 PRNG. The State value is exported so that
 // the generator can be initialized and seeded in a single line of code:
 //
@@ -39,11 +47,8 @@ func (rng *Rng) Seed(seed int64) {
 // Uint64 returns a pseudo-random 64-bit value as a uint64.
 //
 func (rng *Rng) Uint64() uint64 {
-	rng.State += 0x9E3779B97F4A7C15
-	z := rng.State
-	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
-	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
-	return z ^ (z >> 31)
+	rng.State += goldenGamma
+	return mix64(rng.State)
 }
 
 // Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
@@ -51,3 +56,39 @@ func (rng *Rng) Uint64() uint64 {
 func (rng *Rng) Int63() int64 {
 	return int64(rng.Uint64() >> 1)
 }
+
+// Advance advances the generator by n steps, so that the next call to Uint64
+// returns the n-th value following the current position, counting from 0. The
+// generator's state being a simple counter, this runs in constant time. Since
+// the period is 2^64, advancing by 2^64-n, that is by -n in two's complement,
+// moves the generator backwards by n steps.
+//
+func (rng *Rng) Advance(n uint64) {
+	rng.State += n * goldenGamma
+}
+
+// Seek positions the generator at index i of the sequence of a Rng whose
+// initial state is seed, so that the next call to Uint64 returns At(seed, i).
+// This runs in constant time and does not depend on the current state of rng.
+//
+func (rng *Rng) Seek(seed, i uint64) {
+	rng.State = seed + i*goldenGamma
+}
+
+// At returns the i-th value, counting from 0, produced by a Rng whose initial
+// state is seed; that is the value returned by the (i+1)-th call to Uint64 on
+// Rng{seed}. This allows parallel loops to compute element i of a random
+// sequence without sharing state, giving results independent of scheduling.
+//
+func At(seed, i uint64) uint64 {
+	return mix64(seed + (i+1)*goldenGamma)
+}
+
+// mix64 is the output function of splitmix64, a variant of the MurmurHash3
+// 64 bits finalizer.
+//
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return z ^ (z >> 31)
+}
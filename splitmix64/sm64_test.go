@@ -1,6 +1,7 @@
 package splitmix64_test
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/db47h/rand64/v3/splitmix64"
@@ -40,3 +41,134 @@ func TestRng(t *testing.T) {
 		}
 	}
 }
+
+func TestSplittableRng(t *testing.T) {
+	var rng splitmix64.SplittableRng
+	rng.Seed(SEED1)
+	for _, v := range values {
+		n := rng.Uint64()
+		if n != v {
+			t.Fatalf("Expected %X, got %X", v, n)
+		}
+	}
+
+	// Expected values computed with Java's SplittableRandom algorithm.
+	rng.Seed(SEED1)
+	child := rng.Split()
+	if g := child.Gamma(); g != 0x27D8569FFDAEF921 {
+		t.Fatalf("Expected gamma %X, got %X", uint64(0x27D8569FFDAEF921), g)
+	}
+	for _, v := range []uint64{11779121719023325852, 11636964995059546434, 8131146693030241224} {
+		if n := child.Uint64(); n != v {
+			t.Fatalf("child: expected %d, got %d", v, n)
+		}
+	}
+	for _, v := range []uint64{5501893837966540606, 11784247805766568153} {
+		if n := rng.Uint64(); n != v {
+			t.Fatalf("parent: expected %d, got %d", v, n)
+		}
+	}
+	gchild := child.Split()
+	if g := gchild.Gamma(); g != 0xA87FBDBAB2046DC7 {
+		t.Fatalf("Expected gamma %X, got %X", uint64(0xA87FBDBAB2046DC7), g)
+	}
+	for _, v := range []uint64{8789599768291787769, 1919444834869531750} {
+		if n := gchild.Uint64(); n != v {
+			t.Fatalf("grandchild: expected %d, got %d", v, n)
+		}
+	}
+}
+
+func TestSplittableRng_Java(t *testing.T) {
+	var rng splitmix64.SplittableRng
+	rng.Seed(SEED1)
+	for _, v := range values[:3] {
+		if n := rng.NextLong(); n != int64(v) {
+			t.Fatalf("NextLong: expected %d, got %d", int64(v), n)
+		}
+	}
+	for _, v := range values[3:6] {
+		if f := rng.NextDouble(); f != float64(v>>11)/(1<<53) {
+			t.Fatalf("NextDouble: expected %v, got %v", float64(v>>11)/(1<<53), f)
+		}
+	}
+
+	// Expected values computed with Java's SplittableRandom algorithm.
+	rng.Seed(42)
+	for _, v := range []int32{-491277234, 909395113} {
+		if n := rng.NextInt(); n != v {
+			t.Fatalf("NextInt: expected %d, got %d", v, n)
+		}
+	}
+	for i, b := range []int32{10, 10, 16, 1<<31 - 1} {
+		v := []int32{1, 2, 11, 1274364071}[i]
+		if n := rng.NextIntn(b); n != v {
+			t.Fatalf("NextIntn(%d): expected %d, got %d", b, v, n)
+		}
+	}
+}
+
+func TestAt(t *testing.T) {
+	for i, v := range values {
+		if n := splitmix64.At(SEED1, uint64(i)); n != v {
+			t.Fatalf("At(%d): expected %X, got %X", i, v, n)
+		}
+		rng := splitmix64.Rng{State: SEED1}
+		rng.Advance(uint64(i))
+		if n := rng.Uint64(); n != v {
+			t.Fatalf("Advance(%d): expected %X, got %X", i, v, n)
+		}
+		rng.Advance(^uint64(i))
+		if n := rng.Uint64(); n != values[0] {
+			t.Fatalf("Advance(-%d): expected %X, got %X", i+1, values[0], n)
+		}
+		rng.Seek(SEED1, uint64(i))
+		if n := rng.Uint64(); n != v {
+			t.Fatalf("Seek(%d): expected %X, got %X", i, v, n)
+		}
+	}
+}
+
+func TestAtomicRng(t *testing.T) {
+	var rng splitmix64.AtomicRng
+	rng.Seed(SEED1)
+	for _, v := range values {
+		n := rng.Uint64()
+		if n != v {
+			t.Fatalf("Expected %X, got %X", v, n)
+		}
+	}
+
+	// concurrent use: each value must be returned exactly once.
+	const (
+		workers = 8
+		count   = 10000
+	)
+	rng.Seed(SEED1)
+	var (
+		wg  sync.WaitGroup
+		res [workers][count]uint64
+	)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(r *[count]uint64) {
+			defer wg.Done()
+			for i := range r {
+				r[i] = rng.Uint64()
+			}
+		}(&res[w])
+	}
+	wg.Wait()
+	seen := make(map[uint64]bool, workers*count)
+	for w := range res {
+		for _, v := range res[w] {
+			seen[v] = true
+		}
+	}
+	seq := splitmix64.Rng{State: SEED1}
+	for i := 0; i < workers*count; i++ {
+		if v := seq.Uint64(); !seen[v] {
+			t.Fatalf("value %d (%X) not returned", i, v)
+		}
+	}
+}
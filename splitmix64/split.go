@@ -0,0 +1,134 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package splitmix64
+
+import (
+	"math/bits"
+)
+
+// SplittableRng encapsulates a splittable splitmix64 PRNG. Unlike Rng, each
+// instance has its own gamma (the increment added to the state at each step),
+// which allows it to be split into independent child generators, like Java 8's
+// SplittableRandom.
+//
+// The zero value SplittableRng{} uses the same gamma as Rng and produces the
+// same sequence.
+//
+type SplittableRng struct {
+	State uint64 // Internal state value
+	// gamma is XORed with goldenGamma so that the zero value uses the
+	// default gamma.
+	gamma uint64
+}
+
+// Seed uses the provided seed value to initialize the generator to a
+// deterministic state. The gamma is reset to its default value.
+//
+func (rng *SplittableRng) Seed(seed int64) {
+	rng.State = uint64(seed)
+	rng.gamma = 0
+}
+
+// Gamma returns the generator's gamma. It is always odd.
+//
+func (rng *SplittableRng) Gamma() uint64 {
+	return rng.gamma ^ goldenGamma
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (rng *SplittableRng) Uint64() uint64 {
+	rng.State += rng.gamma ^ goldenGamma
+	return mix64(rng.State)
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (rng *SplittableRng) Int63() int64 {
+	return int64(rng.Uint64() >> 1)
+}
+
+// NextLong returns a pseudo-random int64, like SplittableRandom.nextLong().
+//
+func (rng *SplittableRng) NextLong() int64 {
+	return int64(rng.Uint64())
+}
+
+// NextInt returns a pseudo-random int32, like SplittableRandom.nextInt().
+//
+func (rng *SplittableRng) NextInt() int32 {
+	rng.State += rng.gamma ^ goldenGamma
+	return mix32(rng.State)
+}
+
+// NextIntn returns a pseudo-random int32 in [0, bound), like
+// SplittableRandom.nextInt(bound).
+//
+// NextIntn panics if bound <= 0.
+//
+func (rng *SplittableRng) NextIntn(bound int32) int32 {
+	if bound <= 0 {
+		panic("splitmix64: invalid argument to NextIntn")
+	}
+	r := rng.NextInt()
+	m := bound - 1
+	if bound&m == 0 { // power of two
+		return r & m
+	}
+	// reject over-represented candidates
+	for u := int32(uint32(r) >> 1); ; u = int32(uint32(rng.NextInt()) >> 1) {
+		if r = u % bound; u+m-r >= 0 {
+			return r
+		}
+	}
+}
+
+// NextDouble returns a pseudo-random float64 in [0.0, 1.0), like
+// SplittableRandom.nextDouble().
+//
+func (rng *SplittableRng) NextDouble() float64 {
+	return float64(rng.Uint64()>>11) * (1.0 / (1 << 53))
+}
+
+// Split returns a new generator that shares no mutable state with rng. Both
+// generators can then be used independently, for example in separate
+// goroutines or in the subtasks of a fork-join computation. Split advances the
+// state of rng by two steps.
+//
+// The values produced by the new generator have the same statistical
+// properties as those produced by rng, and the set of values produced by both
+// is statistically indistinguishable from the values produced by a single
+// generator.
+//
+func (rng *SplittableRng) Split() *SplittableRng {
+	seed := rng.Uint64()
+	rng.State += rng.gamma ^ goldenGamma
+	return &SplittableRng{
+		State: seed,
+		gamma: mixGamma(rng.State) ^ goldenGamma,
+	}
+}
+
+// mix32 returns the high 32 bits of a variant of mix64, as used by
+// SplittableRandom.nextInt().
+//
+func mix32(z uint64) int32 {
+	z = (z ^ (z >> 33)) * 0x62A9D9ED799705F5
+	return int32(((z ^ (z >> 28)) * 0xCB24D0A5C88C35B3) >> 32)
+}
+
+// mixGamma returns a new gamma value for the given state. The result is always
+// odd and, in order to avoid poor mixing, is adjusted to have enough 01 and 10
+// bit transitions.
+//
+func mixGamma(z uint64) uint64 {
+	z = (z ^ (z >> 33)) * 0xFF51AFD7ED558CCD // MurmurHash3 mix constants
+	z = (z ^ (z >> 33)) * 0xC4CEB9FE1A85EC53
+	z = (z ^ (z >> 33)) | 1 // force to be odd
+	if bits.OnesCount64(z^(z>>1)) < 24 {
+		return z ^ 0xAAAAAAAAAAAAAAAA
+	}
+	return z
+}
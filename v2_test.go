@@ -0,0 +1,93 @@
+//go:build go1.22
+// +build go1.22
+
+package rand64_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/db47h/rand64/v3/cpprand"
+	"github.com/db47h/rand64/v3/erlrand"
+	"github.com/db47h/rand64/v3/gorand"
+	"github.com/db47h/rand64/v3/iorand"
+	"github.com/db47h/rand64/v3/javarand"
+	"github.com/db47h/rand64/v3/juliarand"
+	"github.com/db47h/rand64/v3/luarand"
+	"github.com/db47h/rand64/v3/mt19937"
+	"github.com/db47h/rand64/v3/nprand"
+	"github.com/db47h/rand64/v3/pcg"
+	"github.com/db47h/rand64/v3/pyrand"
+	"github.com/db47h/rand64/v3/splitmix64"
+	"github.com/db47h/rand64/v3/xoroshiro"
+	"github.com/db47h/rand64/v3/xorshift"
+	"github.com/db47h/rand64/v3/xoshiro"
+)
+
+// All generators in this module can be used as a math/rand/v2 Source, except
+// xorshift.MathRandom, which only returns float64 values.
+func TestV2Source(t *testing.T) {
+	var (
+		p   pcg.Rng
+		xss xoroshiro.Rng128SS
+		xp  xoroshiro.Rng128P
+	)
+	xss.Seed(1)
+	xp.Seed(1)
+	sources := map[string]rand.Source{
+		"splitmix64.Rng":           &splitmix64.Rng{},
+		"splitmix64.SplittableRng": &splitmix64.SplittableRng{},
+		"splitmix64.AtomicRng":     &splitmix64.AtomicRng{},
+		"xoshiro.Rng256SS":         &xoshiro.Rng256SS{1},
+		"xoshiro.Rng256PP":         &xoshiro.Rng256PP{1},
+		"xoshiro.Rng256P":          &xoshiro.Rng256P{1},
+		"xoroshiro.Rng128SS":       &xss,
+		"xoroshiro.Rng128P":        &xp,
+		"xorshift.Rng128P":         &xorshift.Rng128P{S0: 1},
+		"pcg.Rng":                  &pcg.Rng{},
+		"pcg.RngStream":            &pcg.RngStream{},
+		"pcg.RngDXSM":              &pcg.RngDXSM{},
+		"pcg.RngXSHRR":             &pcg.RngXSHRR{},
+		"pcg.RngXSHRS":             &pcg.RngXSHRS{},
+		"pcg.RngRXSMXS":            &pcg.RngRXSMXS{},
+		"pcg.RngExt":               pcg.NewExt(2),
+		"pcg.RngLeapfrog":          p.Leapfrog(0, 3),
+		"mt19937.Rng":              &mt19937.Rng{},
+		"mt19937.Rng32":            &mt19937.Rng32{},
+		"pyrand.Rand":              pyrand.New(1),
+		"nprand.Generator":         nprand.DefaultRng(1),
+		"nprand.PCG64":             nprand.NewPCG64(nprand.NewSeedSequence(1)),
+		"nprand.PCG64DXSM":         nprand.NewPCG64DXSM(nprand.NewSeedSequence(1)),
+		"nprand.RandomState":       nprand.NewRandomState(1),
+		"javarand.Rand":            javarand.New(1),
+		"luarand.Rand":             luarand.New(1, 2),
+		"juliarand.TaskLocalRNG":   juliarand.New(1),
+		"cpprand.MinstdRand0":      &cpprand.MinstdRand0{},
+		"cpprand.MinstdRand":       &cpprand.MinstdRand{},
+		"cpprand.Ranlux24Base":     &cpprand.Ranlux24Base{},
+		"cpprand.Ranlux48Base":     &cpprand.Ranlux48Base{},
+		"cpprand.Ranlux24":         &cpprand.Ranlux24{},
+		"cpprand.Ranlux48":         &cpprand.Ranlux48{},
+		"cpprand.KnuthB":           &cpprand.KnuthB{},
+		"erlrand.Exsss":            erlrand.NewExsss(1),
+		"erlrand.Exrop":            erlrand.NewExrop(1),
+		"erlrand.Exs1024s":         erlrand.NewExs1024s(1),
+		"gorand.Rng":               gorand.New(1),
+		"gorand.PCG":               gorand.NewPCG(1, 2),
+		"gorand.ChaCha8":           gorand.NewChaCha8([32]byte{}),
+		"iorand.IoRand":            iorand.New(bytes.NewReader(bytes.Repeat([]byte("rand64"), 1000)), binary.LittleEndian),
+	}
+	for name, src := range sources {
+		r := rand.New(src)
+		for i := 0; i < 100; i++ {
+			if n := r.IntN(10); n < 0 || n >= 10 {
+				t.Fatalf("%s: IntN(10) returned %d", name, n)
+			}
+			if f := r.Float64(); f < 0 || f >= 1 {
+				t.Fatalf("%s: Float64() returned %v", name, f)
+			}
+		}
+	}
+}
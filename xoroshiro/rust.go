@@ -0,0 +1,60 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package xoroshiro
+
+import "github.com/db47h/rand64/v3/internal/rustseed"
+
+// The methods in this file seed the generators like the SeedableRng trait of
+// Rust's rand_core crate. The xoroshiro generators of rand_xoshiro override
+// seed_from_u64 to fill the state with SplitMix64, so rng.Seed(int64(n)) is
+// their equivalent of seed_from_u64(n).
+
+// SeedFromU64Default initializes the generator like the default implementation
+// of SeedableRng::seed_from_u64 in Rust's rand_core, which fills the seed with
+// the output of a PCG32 generator. Generators that override seed_from_u64, like
+// those of rand_xoshiro, are matched by Seed instead.
+//
+func (rng *Rng128SS) SeedFromU64Default(seed uint64) {
+	var s [2]uint64
+	rustseed.FromU64(s[:], seed)
+	rng.s0, rng.s1 = s[0], s[1]
+}
+
+// SeedFromBytes initializes the generator like SeedableRng::from_seed in Rust:
+// seed is read as two little-endian uint64. An all-zero seed is replaced by
+// Seed(0).
+//
+func (rng *Rng128SS) SeedFromBytes(seed [16]byte) {
+	var s [2]uint64
+	if !rustseed.FromBytes(s[:], seed[:]) {
+		rng.Seed(0)
+		return
+	}
+	rng.s0, rng.s1 = s[0], s[1]
+}
+
+// SeedFromU64Default initializes the generator like the default implementation
+// of SeedableRng::seed_from_u64 in Rust's rand_core, which fills the seed with
+// the output of a PCG32 generator. Generators that override seed_from_u64, like
+// those of rand_xoshiro, are matched by Seed instead.
+//
+func (rng *Rng128P) SeedFromU64Default(seed uint64) {
+	var s [2]uint64
+	rustseed.FromU64(s[:], seed)
+	rng.s0, rng.s1 = s[0], s[1]
+}
+
+// SeedFromBytes initializes the generator like SeedableRng::from_seed in Rust:
+// seed is read as two little-endian uint64. An all-zero seed is replaced by
+// Seed(0).
+//
+func (rng *Rng128P) SeedFromBytes(seed [16]byte) {
+	var s [2]uint64
+	if !rustseed.FromBytes(s[:], seed[:]) {
+		rng.Seed(0)
+		return
+	}
+	rng.s0, rng.s1 = s[0], s[1]
+}
@@ -14,8 +14,10 @@ Sebastiano Vigna. For further information: http://xoshiro.di.unimi.it/
 package xoroshiro
 
 import (
+	"math/big"
 	"math/bits"
 
+	"github.com/db47h/rand64/v3/internal/gf2"
 	"github.com/db47h/rand64/v3/splitmix64"
 )
 
@@ -43,7 +45,8 @@ type Rng128P struct {
 }
 
 // Seed uses the provided seed value to initialize the generator to a
-// deterministic state.
+// deterministic state. The state is filled with the output of a SplitMix64
+// generator, like seed_from_u64 in Rust's rand_xoshiro crate.
 //
 func (rng *Rng128P) Seed(seed int64) {
 	src := splitmix64.Rng{}
@@ -71,6 +74,37 @@ func (rng *Rng128P) Int63() int64 {
 	return int64(rng.Uint64() >> 1)
 }
 
+// Jump advances the generator's state by 2^64 steps. It is equivalent to 2^64
+// calls to Uint64; it can be used to generate 2^64 non-overlapping
+// subsequences for parallel computations.
+//
+func (rng *Rng128P) Jump() {
+	rng.s0, rng.s1 = jump(rng.s0, rng.s1, &jumpPoly)
+}
+
+// LongJump advances the generator's state by 2^96 steps. It is equivalent to
+// 2^96 calls to Uint64; it can be used to generate 2^32 starting points, from
+// each of which Jump will generate 2^32 non-overlapping subsequences for
+// parallel distributed computations.
+//
+func (rng *Rng128P) LongJump() {
+	rng.s0, rng.s1 = jump(rng.s0, rng.s1, &longJumpPoly)
+}
+
+// Advance advances the generator's state by n steps. It is equivalent to n
+// calls to Uint64, but only takes O(log n) polynomial operations.
+//
+func (rng *Rng128P) Advance(n uint64) {
+	rng.s0, rng.s1 = advance(rng.s0, rng.s1, new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig advances the generator's state by n steps. If n is negative, the
+// state is moved backwards by -n steps.
+//
+func (rng *Rng128P) AdvanceBig(n *big.Int) {
+	rng.s0, rng.s1 = advance(rng.s0, rng.s1, n)
+}
+
 // Rng128SS encapsulates a xoroshiro128** PRNG.
 //
 // xoroshiro128** 1.0 is Blackman & Vigna's all-purpose, rock-solid, small-state
@@ -85,7 +119,8 @@ type Rng128SS struct {
 }
 
 // Seed uses the provided seed value to initialize the generator to a
-// deterministic state.
+// deterministic state. The state is filled with the output of a SplitMix64
+// generator, like seed_from_u64 in Rust's rand_xoshiro crate.
 //
 func (rng *Rng128SS) Seed(seed int64) {
 	src := splitmix64.Rng{}
@@ -113,3 +148,77 @@ func (rng *Rng128SS) Uint64() uint64 {
 func (rng *Rng128SS) Int63() int64 {
 	return int64(rng.Uint64() >> 1)
 }
+
+// Jump advances the generator's state by 2^64 steps. It is equivalent to 2^64
+// calls to Uint64; it can be used to generate 2^64 non-overlapping
+// subsequences for parallel computations.
+//
+func (rng *Rng128SS) Jump() {
+	rng.s0, rng.s1 = jump(rng.s0, rng.s1, &jumpPoly)
+}
+
+// LongJump advances the generator's state by 2^96 steps. It is equivalent to
+// 2^96 calls to Uint64; it can be used to generate 2^32 starting points, from
+// each of which Jump will generate 2^32 non-overlapping subsequences for
+// parallel distributed computations.
+//
+func (rng *Rng128SS) LongJump() {
+	rng.s0, rng.s1 = jump(rng.s0, rng.s1, &longJumpPoly)
+}
+
+// Advance advances the generator's state by n steps. It is equivalent to n
+// calls to Uint64, but only takes O(log n) polynomial operations.
+//
+func (rng *Rng128SS) Advance(n uint64) {
+	rng.s0, rng.s1 = advance(rng.s0, rng.s1, new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig advances the generator's state by n steps. If n is negative, the
+// state is moved backwards by -n steps.
+//
+func (rng *Rng128SS) AdvanceBig(n *big.Int) {
+	rng.s0, rng.s1 = advance(rng.s0, rng.s1, n)
+}
+
+// charPoly is the ring of polynomials modulo the characteristic polynomial of
+// the xoroshiro128 linear engine.
+//
+var charPoly = gf2.NewMod(128, gf2.Poly{0x095b8f76579aa001, 0x0008828e513b43d5})
+
+var (
+	jumpPoly     = [2]uint64{0xdf900294d8f554a5, 0x170865df4b3201fc}
+	longJumpPoly = [2]uint64{0xd2a98b26625eee7b, 0xdddf9b1090aa7ac1}
+)
+
+// next returns the state following (s0, s1). It is shared by xoroshiro128**
+// and xoroshiro128+ which only differ by their output function.
+//
+func next(s0, s1 uint64) (uint64, uint64) {
+	s1 ^= s0
+	return bits.RotateLeft64(s0, 24) ^ s1 ^ (s1 << 16), bits.RotateLeft64(s1, 37)
+}
+
+// jump returns the state reached by applying the jump polynomial poly to
+// (s0, s1).
+//
+func jump(s0, s1 uint64, poly *[2]uint64) (uint64, uint64) {
+	var j0, j1 uint64
+	for _, p := range poly {
+		for b := uint(0); b < 64; b++ {
+			if p&(1<<b) != 0 {
+				j0 ^= s0
+				j1 ^= s1
+			}
+			s0, s1 = next(s0, s1)
+		}
+	}
+	return j0, j1
+}
+
+// advance returns the state reached by moving (s0, s1) by n steps.
+//
+func advance(s0, s1 uint64, n *big.Int) (uint64, uint64) {
+	var poly [2]uint64
+	copy(poly[:], charPoly.XPow(n))
+	return jump(s0, s1, &poly)
+}
@@ -1,8 +1,11 @@
 package xoroshiro_test
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math/big"
 	"math/rand"
+	"testing"
 
 	"github.com/db47h/rand64/v3/xoroshiro"
 )
@@ -56,3 +59,123 @@ func ExampleRng128SS() {
 	//  17905646702528074117 5693647338227160345 1089260090730707711 12276528025967720504
 	//  41 35 56 61 56 35 31 12 63 54
 }
+
+type jumper interface {
+	rand.Source64
+	Jump()
+	LongJump()
+}
+
+// Expected values generated with refimpl/*_jump.
+func TestJump(t *testing.T) {
+	tests := []struct {
+		name     string
+		rng      jumper
+		jump     [4]uint64
+		longJump [4]uint64
+	}{
+		{"xoroshiro128+", &xoroshiro.Rng128P{},
+			[4]uint64{455215338034927889, 11864508034447034821, 2451145249365978051, 12353327940442194427},
+			[4]uint64{11515747255949227034, 9539944002120240623, 6789287633288612095, 15918313194482807299}},
+		{"xoroshiro128**", &xoroshiro.Rng128SS{},
+			[4]uint64{10156121296504544493, 12420467585289150422, 9699184338940889688, 18398787316145499868},
+			[4]uint64{1877629474249838034, 18082661783909235733, 12371925385346774299, 6495907737536740415}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rng.Seed(SEED1)
+			tt.rng.Jump()
+			for i, v := range tt.jump {
+				if n := tt.rng.Uint64(); n != v {
+					t.Fatalf("Jump: value %d: expected %d, got %d", i, v, n)
+				}
+			}
+			tt.rng.LongJump()
+			for i, v := range tt.longJump {
+				if n := tt.rng.Uint64(); n != v {
+					t.Fatalf("LongJump: value %d: expected %d, got %d", i, v, n)
+				}
+			}
+		})
+	}
+}
+
+type advancer interface {
+	jumper
+	Advance(n uint64)
+	AdvanceBig(n *big.Int)
+}
+
+func TestAdvance(t *testing.T) {
+	for _, rng := range []advancer{&xoroshiro.Rng128P{}, &xoroshiro.Rng128SS{}} {
+		t.Run(fmt.Sprintf("%T", rng), func(t *testing.T) {
+			var want [1000]uint64
+			rng.Seed(SEED1)
+			for i := range want {
+				want[i] = rng.Uint64()
+			}
+			for _, n := range []uint64{0, 1, 2, 63, 64, 65, 500, 999} {
+				rng.Seed(SEED1)
+				rng.Advance(n)
+				if v := rng.Uint64(); v != want[n] {
+					t.Fatalf("Advance(%d): expected %d, got %d", n, want[n], v)
+				}
+				// move back to the start
+				rng.AdvanceBig(big.NewInt(-int64(n) - 1))
+				if v := rng.Uint64(); v != want[0] {
+					t.Fatalf("AdvanceBig(%d): expected %d, got %d", -int64(n)-1, want[0], v)
+				}
+			}
+			// compare with Jump
+			rng.Seed(SEED1)
+			rng.Jump()
+			rng.LongJump()
+			v := rng.Uint64()
+			rng.Seed(SEED1)
+			n := new(big.Int).Lsh(big.NewInt(1), 96)
+			rng.AdvanceBig(n.Add(n, new(big.Int).Lsh(big.NewInt(1), 64)))
+			if w := rng.Uint64(); v != w {
+				t.Fatalf("AdvanceBig: expected %d, got %d", v, w)
+			}
+		})
+	}
+}
+
+// Expected values derived from the seeds generated by Rust's rand_core 0.9.
+func TestRust(t *testing.T) {
+	// state words filled by rand_core's default seed_from_u64(42)
+	s0, s1 := uint64(737801269571325860), uint64(13264228356429297898)
+	var rng xoroshiro.Rng128P
+	rng.SeedFromU64Default(42)
+	if v := rng.Uint64(); v != s0+s1 {
+		t.Fatalf("SeedFromU64Default: expected %d, got %d", s0+s1, v)
+	}
+
+	var seed [16]byte
+	binary.LittleEndian.PutUint64(seed[:], s0)
+	binary.LittleEndian.PutUint64(seed[8:], s1)
+	rng.SeedFromBytes(seed)
+	if v := rng.Uint64(); v != s0+s1 {
+		t.Fatalf("SeedFromBytes: expected %d, got %d", s0+s1, v)
+	}
+
+	var ss, ref xoroshiro.Rng128SS
+	ss.SeedFromU64Default(42)
+	ref.SeedFromBytes(seed)
+	if a, b := ss.Uint64(), ref.Uint64(); a != b {
+		t.Fatalf("SeedFromU64Default and SeedFromBytes mismatch: %d != %d", a, b)
+	}
+	ss.SeedFromBytes([16]byte{})
+	ref.Seed(0)
+	if a, b := ss.Uint64(), ref.Uint64(); a != b {
+		t.Fatalf("SeedFromBytes(zero): expected %d, got %d", b, a)
+	}
+
+	// seed_from_u64(42) in rand_xoshiro uses SplitMix64, like Seed: these are
+	// the first state words of rand's SmallRng::seed_from_u64(42).
+	s0, s1 = 13679457532755275413, 2949826092126892291
+	rng.Seed(42)
+	if v := rng.Uint64(); v != s0+s1 {
+		t.Fatalf("Seed: expected %d, got %d", s0+s1, v)
+	}
+}
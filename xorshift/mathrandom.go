@@ -0,0 +1,67 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package xorshift
+
+const cacheSize = 64
+
+// MathRandom reproduces V8's implementation of Math.random. V8 generates
+// values in batches of 64 with Rng128P.Float64 and stores them in a cache that
+// is consumed in reverse order: the first value returned is the last one
+// generated.
+//
+// A zero MathRandom must be seeded or have its state set before use.
+//
+type MathRandom struct {
+	rng   Rng128P
+	cache [cacheSize]float64
+	index int
+}
+
+// NewMathRandom returns a new MathRandom seeded with seed.
+//
+func NewMathRandom(seed int64) *MathRandom {
+	r := &MathRandom{}
+	r.Seed(seed)
+	return r
+}
+
+// Seed initializes the state of r like V8 started with --random_seed=seed and
+// empties the cache.
+//
+func (r *MathRandom) Seed(seed int64) {
+	r.rng.Seed(seed)
+	r.index = 0
+}
+
+// Float64 returns a pseudo-random float64 in [0.0, 1.0), the same value as the
+// next call to Math.random() in V8.
+//
+func (r *MathRandom) Float64() float64 {
+	if r.index == 0 {
+		for i := range r.cache {
+			r.cache[i] = r.rng.Float64()
+		}
+		r.index = cacheSize
+	}
+	r.index--
+	return r.cache[r.index]
+}
+
+// SetState sets the state of the underlying Rng128P to s0, s1 and empties the
+// cache. The next call to Float64 refills the cache from this state, like V8
+// does when the cache is exhausted.
+//
+func (r *MathRandom) SetState(s0, s1 uint64) {
+	r.rng = Rng128P{s0, s1}
+	r.index = 0
+}
+
+// State returns the state of the underlying Rng128P, that is the state used
+// for the next refill of the cache, and the number of values left in the
+// cache.
+//
+func (r *MathRandom) State() (s0, s1 uint64, cached int) {
+	return r.rng.S0, r.rng.S1, r.index
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+/*
+Package xorshift provides an implementation of the xorshift128+ pseudo-random
+number generator as used by the V8 JavaScript engine.
+
+Period: 2^128-1. State size: 128 bits.
+
+MathRandom reproduces the sequence of values returned by Math.random() in V8
+(Chrome, Node.js, Deno) for a given seed or internal state. For example, the
+values returned by
+
+	node --random_seed=42 -e 'console.log(Math.random())'
+
+are also returned by xorshift.NewMathRandom(42).Float64().
+
+xorshift128+ has been superseded by xoroshiro128+, see the xoroshiro package.
+*/
+package xorshift
+
+import (
+	"math"
+)
+
+// Rng128P encapsulates a xorshift128+ PRNG with the shift triple (23, 17, 26)
+// used by V8. The state is exported so that the generator can be set to a
+// known V8 state.
+//
+// The state must not be zero everywhere.
+//
+type Rng128P struct {
+	S0, S1 uint64
+}
+
+// Seed uses the provided seed value to initialize the generator to a
+// deterministic state. The state is derived from the seed with the
+// MurmurHash3 finalizer, exactly like V8 does for Math.random when started
+// with --random_seed=seed.
+//
+func (rng *Rng128P) Seed(seed int64) {
+	rng.S0 = murmurHash3(uint64(seed))
+	rng.S1 = murmurHash3(^uint64(seed))
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (rng *Rng128P) Uint64() uint64 {
+	r := rng.S0 + rng.S1
+	rng.next()
+	return r
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (rng *Rng128P) Int63() int64 {
+	return int64(rng.Uint64() >> 1)
+}
+
+// Float64 returns a pseudo-random float64 in [0.0, 1.0). This is the value
+// computed by V8 from each step of the generator: the 52 high bits of S0 after
+// the step are used as the mantissa of a float64 in [1.0, 2.0), then 1 is
+// subtracted.
+//
+func (rng *Rng128P) Float64() float64 {
+	rng.next()
+	return toDouble(rng.S0)
+}
+
+func (rng *Rng128P) next() {
+	s1, s0 := rng.S0, rng.S1
+	rng.S0 = s0
+	s1 ^= s1 << 23
+	s1 ^= s1 >> 17
+	s1 ^= s0
+	s1 ^= s0 >> 26
+	rng.S1 = s1
+}
+
+func toDouble(s0 uint64) float64 {
+	return math.Float64frombits(s0>>12|0x3FF0000000000000) - 1
+}
+
+func murmurHash3(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xFF51AFD7ED558CCD
+	h ^= h >> 33
+	h *= 0xC4CEB9FE1A85EC53
+	h ^= h >> 33
+	return h
+}
@@ -0,0 +1,81 @@
+package xorshift_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/db47h/rand64/v3/xorshift"
+)
+
+func ExampleMathRandom() {
+	// node --random_seed=42 -e 'console.log(Math.random(), Math.random())'
+	r := xorshift.NewMathRandom(42)
+	fmt.Println(r.Float64(), r.Float64())
+
+	// Output:
+	// 0.7939112874678715 0.5254990606499601
+}
+
+// Expected values generated with Node.js v20 (V8 11.3).
+func TestMathRandom(t *testing.T) {
+	tests := []struct {
+		seed int64
+		want map[int]float64
+	}{
+		{42, map[int]float64{0: 0.7939112874678715, 1: 0.5254990606499601, 63: 0.08156904043271651,
+			64: 0.4706713645501157, 65: 0.2603201442309373, 129: 0.22660586183432097}},
+		{-7, map[int]float64{0: 0.24093511597397543, 1: 0.5146862225679596, 63: 0.9090072926551196,
+			64: 0.519324725871884, 65: 0.3903917043451002, 129: 0.2776623616166647}},
+	}
+	for _, tt := range tests {
+		r := xorshift.NewMathRandom(tt.seed)
+		for i := 0; i < 130; i++ {
+			v := r.Float64()
+			if w, ok := tt.want[i]; ok && v != w {
+				t.Fatalf("seed %d, value %d: expected %v, got %v", tt.seed, i, w, v)
+			}
+		}
+	}
+}
+
+func TestMathRandom_SetState(t *testing.T) {
+	r := xorshift.NewMathRandom(42)
+	r.Float64()
+	s0, s1, n := r.State()
+	if n != 63 {
+		t.Fatalf("expected 63 cached values, got %d", n)
+	}
+	want := make([]float64, 64)
+	for i := range want {
+		want[i] = r.Float64()
+	}
+	r.SetState(s0, s1)
+	for i := range want[63:] {
+		if v := r.Float64(); v != want[63+i] {
+			t.Fatalf("expected %v, got %v", want[63+i], v)
+		}
+	}
+	// the cache is filled with Rng128P.Float64 and consumed in reverse order
+	rng := xorshift.Rng128P{S0: s0, S1: s1}
+	for i := 63; i >= 0; i-- {
+		want[i] = rng.Float64()
+	}
+	r.SetState(s0, s1)
+	for i := range want {
+		if v := r.Float64(); v != want[i] {
+			t.Fatalf("expected %v, got %v", want[i], v)
+		}
+	}
+}
+
+func TestRng128P(t *testing.T) {
+	var rng xorshift.Rng128P
+	rng.Seed(42)
+	s0, s1 := rng.S0, rng.S1
+	if v := rng.Uint64(); v != s0+s1 {
+		t.Fatalf("expected %d, got %d", s0+s1, v)
+	}
+	if rng.S0 != s1 {
+		t.Fatalf("expected S0 = %d, got %d", s1, rng.S0)
+	}
+}
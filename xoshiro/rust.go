@@ -0,0 +1,69 @@
+// Copyright (c) 2014-2019, Denis Bernard <db047h@gmail.com>
+// Use of this source code is governed by the ISC license that
+// can be found in the LICENSE file.
+
+package xoshiro
+
+import "github.com/db47h/rand64/v3/internal/rustseed"
+
+// The methods in this file seed the generators like the SeedableRng trait of
+// Rust's rand_core crate. The xoshiro generators of rand_xoshiro, and rand's
+// SmallRng, override seed_from_u64 to fill the state with SplitMix64, so
+// rng.Seed(int64(n)) is their equivalent of seed_from_u64(n).
+
+// SeedFromU64Default initializes the generator like the default implementation
+// of SeedableRng::seed_from_u64 in Rust's rand_core, which fills the seed with
+// the output of a PCG32 generator. Generators that override seed_from_u64, like
+// those of rand_xoshiro, are matched by Seed instead.
+//
+func (rng *Rng256SS) SeedFromU64Default(seed uint64) {
+	rustseed.FromU64(rng[:], seed)
+}
+
+// SeedFromBytes initializes the generator like SeedableRng::from_seed in Rust:
+// seed is read as four little-endian uint64. An all-zero seed is replaced by
+// Seed(0).
+//
+func (rng *Rng256SS) SeedFromBytes(seed [32]byte) {
+	if !rustseed.FromBytes(rng[:], seed[:]) {
+		rng.Seed(0)
+	}
+}
+
+// SeedFromU64Default initializes the generator like the default implementation
+// of SeedableRng::seed_from_u64 in Rust's rand_core, which fills the seed with
+// the output of a PCG32 generator. Generators that override seed_from_u64, like
+// those of rand_xoshiro, are matched by Seed instead.
+//
+func (rng *Rng256PP) SeedFromU64Default(seed uint64) {
+	rustseed.FromU64(rng[:], seed)
+}
+
+// SeedFromBytes initializes the generator like SeedableRng::from_seed in Rust:
+// seed is read as four little-endian uint64. An all-zero seed is replaced by
+// Seed(0).
+//
+func (rng *Rng256PP) SeedFromBytes(seed [32]byte) {
+	if !rustseed.FromBytes(rng[:], seed[:]) {
+		rng.Seed(0)
+	}
+}
+
+// SeedFromU64Default initializes the generator like the default implementation
+// of SeedableRng::seed_from_u64 in Rust's rand_core, which fills the seed with
+// the output of a PCG32 generator. Generators that override seed_from_u64, like
+// those of rand_xoshiro, are matched by Seed instead.
+//
+func (rng *Rng256P) SeedFromU64Default(seed uint64) {
+	rustseed.FromU64(rng[:], seed)
+}
+
+// SeedFromBytes initializes the generator like SeedableRng::from_seed in Rust:
+// seed is read as four little-endian uint64. An all-zero seed is replaced by
+// Seed(0).
+//
+func (rng *Rng256P) SeedFromBytes(seed [32]byte) {
+	if !rustseed.FromBytes(rng[:], seed[:]) {
+		rng.Seed(0)
+	}
+}
@@ -4,7 +4,8 @@
 
 /*
 Package xoshiro provides an implementation for a pseudo-random number
-generator (PRNG) using the xoshiro256** and xoshiro256+ algorithms.
+generator (PRNG) using the xoshiro256**, xoshiro256++ and xoshiro256+
+algorithms.
 
 Period: 2^256-1. State size: 256 bits.
 
@@ -14,8 +15,10 @@ Sebastiano Vigna. For further information: http://xoshiro.di.unimi.it/
 package xoshiro
 
 import (
+	"math/big"
 	"math/bits"
 
+	"github.com/db47h/rand64/v3/internal/gf2"
 	"github.com/db47h/rand64/v3/splitmix64"
 )
 
@@ -30,7 +33,8 @@ import (
 type Rng256SS [4]uint64
 
 // Seed uses the provided seed value to initialize the generator to a
-// deterministic state.
+// deterministic state. The state is filled with the output of a SplitMix64
+// generator, like seed_from_u64 in Rust's rand_xoshiro crate.
 //
 func (rng *Rng256SS) Seed(seed int64) {
 	src := splitmix64.Rng{}
@@ -66,6 +70,37 @@ func (rng *Rng256SS) Int63() int64 {
 	return int64(rng.Uint64() >> 1)
 }
 
+// Jump advances the generator's state by 2^128 steps. It is equivalent to 2^128
+// calls to Uint64; it can be used to generate 2^128 non-overlapping
+// subsequences for parallel computations.
+//
+func (rng *Rng256SS) Jump() {
+	jump((*[4]uint64)(rng), &jumpPoly)
+}
+
+// LongJump advances the generator's state by 2^192 steps. It is equivalent to
+// 2^192 calls to Uint64; it can be used to generate 2^64 starting points, from
+// each of which Jump will generate 2^64 non-overlapping subsequences for
+// parallel distributed computations.
+//
+func (rng *Rng256SS) LongJump() {
+	jump((*[4]uint64)(rng), &longJumpPoly)
+}
+
+// Advance advances the generator's state by n steps. It is equivalent to n
+// calls to Uint64, but only takes O(log n) polynomial operations.
+//
+func (rng *Rng256SS) Advance(n uint64) {
+	advance((*[4]uint64)(rng), new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig advances the generator's state by n steps. If n is negative, the
+// state is moved backwards by -n steps.
+//
+func (rng *Rng256SS) AdvanceBig(n *big.Int) {
+	advance((*[4]uint64)(rng), n)
+}
+
 // Rng256P encapsulates a xoshiro256+ PRNG.
 //
 // xoshiro256+ 1.0 is Blackman & Vigna's best and fastest generator for
@@ -84,7 +119,8 @@ func (rng *Rng256SS) Int63() int64 {
 type Rng256P [4]uint64
 
 // Seed uses the provided seed value to initialize the generator to a
-// deterministic state.
+// deterministic state. The state is filled with the output of a SplitMix64
+// generator, like seed_from_u64 in Rust's rand_xoshiro crate.
 //
 func (rng *Rng256P) Seed(seed int64) {
 	src := splitmix64.Rng{}
@@ -118,3 +154,155 @@ func (rng *Rng256P) Uint64() uint64 {
 func (rng *Rng256P) Int63() int64 {
 	return int64(rng.Uint64() >> 1)
 }
+
+// Jump advances the generator's state by 2^128 steps. It is equivalent to 2^128
+// calls to Uint64; it can be used to generate 2^128 non-overlapping
+// subsequences for parallel computations.
+//
+func (rng *Rng256P) Jump() {
+	jump((*[4]uint64)(rng), &jumpPoly)
+}
+
+// LongJump advances the generator's state by 2^192 steps. It is equivalent to
+// 2^192 calls to Uint64; it can be used to generate 2^64 starting points, from
+// each of which Jump will generate 2^64 non-overlapping subsequences for
+// parallel distributed computations.
+//
+func (rng *Rng256P) LongJump() {
+	jump((*[4]uint64)(rng), &longJumpPoly)
+}
+
+// Advance advances the generator's state by n steps. It is equivalent to n
+// calls to Uint64, but only takes O(log n) polynomial operations.
+//
+func (rng *Rng256P) Advance(n uint64) {
+	advance((*[4]uint64)(rng), new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig advances the generator's state by n steps. If n is negative, the
+// state is moved backwards by -n steps.
+//
+func (rng *Rng256P) AdvanceBig(n *big.Int) {
+	advance((*[4]uint64)(rng), n)
+}
+
+// Rng256PP encapsulates a xoshiro256++ PRNG.
+//
+// xoshiro256++ 1.0 is one of Blackman & Vigna's all-purpose, rock-solid
+// generators. It has excellent (sub-ns) speed, a state (256 bits) that is large
+// enough for any parallel application, and it passes all tests the authors are
+// aware of. It is the default generator of Julia and of Rust's SmallRng.
+//
+// For generating just floating-point numbers, xoshiro256+ is even faster.
+//
+type Rng256PP [4]uint64
+
+// Seed uses the provided seed value to initialize the generator to a
+// deterministic state. The state is filled with the output of a SplitMix64
+// generator, like seed_from_u64 in Rust's rand_xoshiro crate.
+//
+func (rng *Rng256PP) Seed(seed int64) {
+	src := splitmix64.Rng{}
+	src.Seed(seed)
+	rng[0] = src.Uint64()
+	rng[1] = src.Uint64()
+	rng[2] = src.Uint64()
+	rng[3] = src.Uint64()
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+//
+func (rng *Rng256PP) Uint64() uint64 {
+	result := bits.RotateLeft64(rng[0]+rng[3], 23) + rng[0]
+	next((*[4]uint64)(rng))
+	return result
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+//
+func (rng *Rng256PP) Int63() int64 {
+	return int64(rng.Uint64() >> 1)
+}
+
+// Jump advances the generator's state by 2^128 steps. It is equivalent to 2^128
+// calls to Uint64; it can be used to generate 2^128 non-overlapping
+// subsequences for parallel computations.
+//
+func (rng *Rng256PP) Jump() {
+	jump((*[4]uint64)(rng), &jumpPoly)
+}
+
+// LongJump advances the generator's state by 2^192 steps. It is equivalent to
+// 2^192 calls to Uint64; it can be used to generate 2^64 starting points, from
+// each of which Jump will generate 2^64 non-overlapping subsequences for
+// parallel distributed computations.
+//
+func (rng *Rng256PP) LongJump() {
+	jump((*[4]uint64)(rng), &longJumpPoly)
+}
+
+// Advance advances the generator's state by n steps. It is equivalent to n
+// calls to Uint64, but only takes O(log n) polynomial operations.
+//
+func (rng *Rng256PP) Advance(n uint64) {
+	advance((*[4]uint64)(rng), new(big.Int).SetUint64(n))
+}
+
+// AdvanceBig advances the generator's state by n steps. If n is negative, the
+// state is moved backwards by -n steps.
+//
+func (rng *Rng256PP) AdvanceBig(n *big.Int) {
+	advance((*[4]uint64)(rng), n)
+}
+
+// charPoly is the ring of polynomials modulo the characteristic polynomial of
+// the xoshiro256 linear engine.
+//
+var charPoly = gf2.NewMod(256, gf2.Poly{0x9d116f2bb0f0f001, 0x0280002bcefd1a5e, 0x04b4edcf26259f85, 0x0003c03c3f3ecb19})
+
+var (
+	jumpPoly     = [4]uint64{0x180ec6d33cfd0aba, 0xd5a61266f0c9392c, 0xa9582618e03fc9aa, 0x39abdc4529b1661c}
+	longJumpPoly = [4]uint64{0x76e15d3efefdcbbf, 0xc5004e441c522fb3, 0x77710069854ee241, 0x39109bb02acbe635}
+)
+
+// next advances the state s by one step. It is shared by xoshiro256**,
+// xoshiro256++ and xoshiro256+ which only differ by their output function.
+//
+func next(s *[4]uint64) {
+	t := s[1] << 17
+
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+
+	s[2] ^= t
+
+	s[3] = bits.RotateLeft64(s[3], 45)
+}
+
+// jump computes the state reached by applying the jump polynomial poly to s.
+//
+func jump(s *[4]uint64, poly *[4]uint64) {
+	var s0, s1, s2, s3 uint64
+	for _, p := range poly {
+		for b := uint(0); b < 64; b++ {
+			if p&(1<<b) != 0 {
+				s0 ^= s[0]
+				s1 ^= s[1]
+				s2 ^= s[2]
+				s3 ^= s[3]
+			}
+			next(s)
+		}
+	}
+	s[0], s[1], s[2], s[3] = s0, s1, s2, s3
+}
+
+// advance moves the state s by n steps.
+//
+func advance(s *[4]uint64, n *big.Int) {
+	var poly [4]uint64
+	copy(poly[:], charPoly.XPow(n))
+	jump(s, &poly)
+}
@@ -2,7 +2,9 @@ package xoshiro_test
 
 import (
 	"fmt"
+	"math/big"
 	"math/rand"
+	"testing"
 
 	"github.com/db47h/rand64/v3/xoshiro"
 )
@@ -56,3 +58,191 @@ func ExampleRng256SS() {
 	//  14206081294295289219 1400819388980187612 655760235528857176 11230280953057933127
 	//  11 13 64 51 53 15 16 55 12 61
 }
+
+func ExampleRng256PP() {
+	src := xoshiro.Rng256PP{}
+	src.Seed(SEED1)
+	rng := rand.New(&src)
+	for i := 0; i < 4; i++ {
+		fmt.Printf(" %d", rng.Uint32())
+	}
+	fmt.Println("")
+	for i := 0; i < 4; i++ {
+		fmt.Printf(" %d", rng.Uint64())
+	}
+	fmt.Println("")
+	// Play craps
+	for i := 0; i < 10; i++ {
+		fmt.Printf(" %d%d", rng.Intn(6)+1, rng.Intn(6)+1)
+	}
+
+	// Output:
+	// 25948297 551636419 1113478187 2577151953
+	//  3985139119203436879 9125792409491578579 14818553638672731488 11259017051931957972
+	//  26 41 11 36 11 56 56 42 42 52
+}
+
+type jumper interface {
+	rand.Source64
+	Jump()
+	LongJump()
+}
+
+// Expected values generated with refimpl/*_jump.
+func TestJump(t *testing.T) {
+	tests := []struct {
+		name     string
+		rng      jumper
+		jump     [4]uint64
+		longJump [4]uint64
+	}{
+		{"xoshiro256+", &xoshiro.Rng256P{},
+			[4]uint64{13019173676340704044, 9149649913396906106, 4163673110770049064, 168880516510138962},
+			[4]uint64{1931816647780984834, 15478871055513231480, 16616830294069767623, 16886299478962038895}},
+		{"xoshiro256++", &xoshiro.Rng256PP{},
+			[4]uint64{15646588615614774958, 16422084260879643204, 462832918165517731, 3870408092964839169},
+			[4]uint64{8743901459893588171, 10228579121800082244, 14523540486434201090, 11334944677218092658}},
+		{"xoshiro256**", &xoshiro.Rng256SS{},
+			[4]uint64{4912643752023559091, 15330732488072201317, 7606963992599195487, 10491161793800539595},
+			[4]uint64{697740351196513796, 10150774482595138178, 8814107189659281013, 2382338700075688139}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rng.Seed(SEED1)
+			tt.rng.Jump()
+			for i, v := range tt.jump {
+				if n := tt.rng.Uint64(); n != v {
+					t.Fatalf("Jump: value %d: expected %d, got %d", i, v, n)
+				}
+			}
+			tt.rng.LongJump()
+			for i, v := range tt.longJump {
+				if n := tt.rng.Uint64(); n != v {
+					t.Fatalf("LongJump: value %d: expected %d, got %d", i, v, n)
+				}
+			}
+		})
+	}
+}
+
+type advancer interface {
+	jumper
+	Advance(n uint64)
+	AdvanceBig(n *big.Int)
+}
+
+func TestAdvance(t *testing.T) {
+	for _, rng := range []advancer{&xoshiro.Rng256P{}, &xoshiro.Rng256PP{}, &xoshiro.Rng256SS{}} {
+		t.Run(fmt.Sprintf("%T", rng), func(t *testing.T) {
+			var want [1000]uint64
+			rng.Seed(SEED1)
+			for i := range want {
+				want[i] = rng.Uint64()
+			}
+			for _, n := range []uint64{0, 1, 2, 63, 64, 65, 500, 999} {
+				rng.Seed(SEED1)
+				rng.Advance(n)
+				if v := rng.Uint64(); v != want[n] {
+					t.Fatalf("Advance(%d): expected %d, got %d", n, want[n], v)
+				}
+				// move back to the start
+				rng.AdvanceBig(big.NewInt(-int64(n) - 1))
+				if v := rng.Uint64(); v != want[0] {
+					t.Fatalf("AdvanceBig(%d): expected %d, got %d", -int64(n)-1, want[0], v)
+				}
+			}
+			// compare with Jump
+			rng.Seed(SEED1)
+			rng.Jump()
+			rng.LongJump()
+			v := rng.Uint64()
+			rng.Seed(SEED1)
+			n := new(big.Int).Lsh(big.NewInt(1), 192)
+			rng.AdvanceBig(n.Add(n, new(big.Int).Lsh(big.NewInt(1), 128)))
+			if w := rng.Uint64(); v != w {
+				t.Fatalf("AdvanceBig: expected %d, got %d", v, w)
+			}
+		})
+	}
+}
+
+// Expected values generated with Rust's rand 0.9 and rand_chacha 0.9.
+func TestRust(t *testing.T) {
+	// rand_core's default seed_from_u64 (PCG32)
+	for _, tt := range []struct {
+		seed uint64
+		want xoshiro.Rng256SS
+	}{
+		{0, xoshiro.Rng256SS{5029875928683246316, 12496553309261721735, 7486978417156673744, 18337965915673107442}},
+		{42, xoshiro.Rng256SS{737801269571325860, 13264228356429297898, 6122560235581770795, 17447191129487197325}},
+		{1<<64 - 1, xoshiro.Rng256SS{1164199018802333487, 4854591418228626485, 4018861249519741338, 4494909378193226339}},
+	} {
+		var rng xoshiro.Rng256SS
+		rng.SeedFromU64Default(tt.seed)
+		if rng != tt.want {
+			t.Fatalf("SeedFromU64Default(%d): expected state %v, got %v", tt.seed, tt.want, rng)
+		}
+	}
+
+	// rand_xoshiro and SmallRng override seed_from_u64 with SplitMix64. The
+	// state is the one printed by SmallRng's Debug implementation.
+	for _, tt := range []struct {
+		seed uint64
+		want xoshiro.Rng256PP
+	}{
+		{0, xoshiro.Rng256PP{16294208416658607535, 7960286522194355700, 487617019471545679, 17909611376780542444}},
+		{42, xoshiro.Rng256PP{13679457532755275413, 2949826092126892291, 5139283748462763858, 6349198060258255764}},
+		{1<<64 - 1, xoshiro.Rng256PP{16490336266968443936, 16834447057089888969, 4048727598324417001, 7862637804313477842}},
+	} {
+		var rng xoshiro.Rng256PP
+		rng.Seed(int64(tt.seed))
+		if rng != tt.want {
+			t.Fatalf("Seed(%d): expected state %v, got %v", int64(tt.seed), tt.want, rng)
+		}
+		var ss xoshiro.Rng256SS
+		ss.Seed(int64(tt.seed))
+		if xoshiro.Rng256PP(ss) != tt.want {
+			t.Fatalf("Seed(%d): expected state %v, got %v", int64(tt.seed), tt.want, ss)
+		}
+	}
+	for _, tt := range []struct {
+		seed int64
+		want [2]uint64
+	}{
+		{0, [2]uint64{5987356902031041503, 7051070477665621255}},
+		{42, [2]uint64{15021278609987233951, 5881210131331364753}},
+		{-1, [2]uint64{6254647548650071986, 16610832622747802512}},
+	} {
+		var rng xoshiro.Rng256PP
+		rng.Seed(tt.seed)
+		for i, w := range tt.want {
+			if v := rng.Uint64(); v != w {
+				t.Fatalf("Seed(%d): value %d: expected %d, got %d", tt.seed, i, w, v)
+			}
+		}
+	}
+
+	// SmallRng::from_seed
+	var seed [32]byte
+	for i := range seed {
+		seed[i] = byte(i*7 + 1)
+	}
+	var rng xoshiro.Rng256PP
+	rng.SeedFromBytes(seed)
+	for i, w := range []uint64{11787476555269506937, 15319061860893871990} {
+		if v := rng.Uint64(); v != w {
+			t.Fatalf("SeedFromBytes: value %d: expected %d, got %d", i, w, v)
+		}
+	}
+	rng.SeedFromBytes([32]byte{})
+	if v := rng.Uint64(); v != 5987356902031041503 {
+		t.Fatalf("SeedFromBytes(zero): expected 5987356902031041503, got %d", v)
+	}
+	// reference test of rand's Xoshiro256PlusPlus
+	rng.SeedFromBytes([32]byte{0: 1, 8: 2, 16: 3, 24: 4})
+	for i, w := range []uint64{41943041, 58720359, 3588806011781223, 3591011842654386, 9228616714210784205} {
+		if v := rng.Uint64(); v != w {
+			t.Fatalf("SeedFromBytes: value %d: expected %d, got %d", i, w, v)
+		}
+	}
+}